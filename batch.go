@@ -0,0 +1,403 @@
+package dbx
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"strings"
+)
+
+// batchAlias names the rows joined to the table by BatchUpdateStmt.
+const batchAlias = "_batch"
+
+// BatchUpdateStmt builds an `UPDATE ...` of many rows with different values.
+type BatchUpdateStmt struct {
+	runner
+	EventReceiver
+	Dialect
+
+	Table            string
+	KeyColumn        []string
+	Column           []string
+	elemType         reflect.Type
+	maxAllowedPacket int
+	records          []reflect.Value
+	value            [][]interface{}
+	inTx             bool
+}
+
+// BatchUpdate creates a BatchUpdateStmt that updates a row for each struct
+// in rows, which can be a slice of structs or pointers to structs.
+// Rows are matched by keyColumn, and the other columns of the structs are set.
+// Columns are named by `db` tag or NameMapping like in Record.
+// table can be qualified by a schema like `schema.table`, and have an alias.
+//
+// It is built as `UPDATE ... FROM (VALUES ...)` in PostgreSQL,
+// `UPDATE ... JOIN (SELECT ... UNION ALL ...)` in MySQL, and `CASE` elsewhere.
+// In PostgreSQL, the rows follow an empty `SELECT` of the table
+// by `UNION ALL`, so that the values take the types of the columns,
+// like timestamp or uuid, instead of text.
+func BatchUpdate(table string, keyColumn []string, rows interface{}) *BatchUpdateStmt {
+	b := &BatchUpdateStmt{
+		Table:     table,
+		KeyColumn: keyColumn,
+	}
+	v := reflect.Indirect(reflect.ValueOf(rows))
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return b
+	}
+	t := v.Type().Elem()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct {
+		b.elemType = t
+	}
+	for i := 0; i < v.Len(); i++ {
+		elem := reflect.Indirect(v.Index(i))
+		if elem.Kind() == reflect.Struct {
+			b.records = append(b.records, elem)
+		}
+	}
+	return b
+}
+
+// BatchUpdate creates a BatchUpdateStmt.
+func (sess *Session) BatchUpdate(table string, keyColumn []string, rows interface{}) *BatchUpdateStmt {
+	b := BatchUpdate(table, keyColumn, rows)
+	b.runner = sess
+	b.EventReceiver = sess.EventReceiver
+	b.Dialect = sess.Dialect
+	b.maxAllowedPacket = sess.maxAllowedPacket
+	return b
+}
+
+// BatchUpdate creates a BatchUpdateStmt.
+func (tx *Tx) BatchUpdate(table string, keyColumn []string, rows interface{}) *BatchUpdateStmt {
+	b := BatchUpdate(table, keyColumn, rows)
+	b.runner = tx
+	b.EventReceiver = tx.EventReceiver
+	b.Dialect = tx.Dialect
+	b.maxAllowedPacket = tx.maxAllowedPacket
+	return b
+}
+
+// Columns specifies the columns to set, instead of all but the keys.
+func (b *BatchUpdateStmt) Columns(column ...string) *BatchUpdateStmt {
+	b.Column = column
+	return b
+}
+
+// InTransaction wraps the statements of a chunked update in a transaction,
+// so that either all or none of the rows are updated.
+// It has no effect if the BatchUpdateStmt is created by Tx.
+func (b *BatchUpdateStmt) InTransaction() *BatchUpdateStmt {
+	b.inTx = true
+	return b
+}
+
+// columns returns the columns to set.
+func (b *BatchUpdateStmt) columns() []string {
+	if len(b.Column) > 0 || b.elemType == nil {
+		return b.Column
+	}
+	var column []string
+	all, _ := newTagStore().columns(reflect.New(b.elemType).Elem())
+	for _, col := range all {
+		if !containsString(b.KeyColumn, col) {
+			column = append(column, col)
+		}
+	}
+	return column
+}
+
+// values returns the keys followed by the values of columns for each row.
+func (b *BatchUpdateStmt) values(column []string) [][]interface{} {
+	if b.value != nil {
+		return b.value
+	}
+	s := newTagStore()
+	name := append(append([]string{}, b.KeyColumn...), column...)
+	value := make([][]interface{}, len(b.records))
+	for i, v := range b.records {
+		found := make([]interface{}, len(name))
+		s.findValueByName(v, name, found, false)
+		for j, f := range found {
+			if f != nil {
+				found[j] = f.(reflect.Value).Interface()
+			}
+		}
+		value[i] = found
+	}
+	return value
+}
+
+// params tells the number of bind parameters for a row in d.
+func (b *BatchUpdateStmt) params(d Dialect, column []string) int {
+	switch d.DriverName() {
+	case "postgres", "mysql":
+		return len(b.KeyColumn) + len(column)
+	default:
+		return (len(b.KeyColumn)+1)*len(column) + len(b.KeyColumn)
+	}
+}
+
+func (b *BatchUpdateStmt) Build(d Dialect, buf Buffer) error {
+	if b.Table == "" {
+		return ErrTableNotSpecified
+	}
+	column := b.columns()
+	if len(b.KeyColumn) == 0 || len(column) == 0 {
+		return ErrColumnNotSpecified
+	}
+	value := b.values(column)
+	if len(value) == 0 {
+		return ErrInvalidSliceLength
+	}
+
+	switch d.DriverName() {
+	case "postgres":
+		return b.buildValues(d, buf, column, value)
+	case "mysql":
+		return b.buildJoin(d, buf, column, value)
+	default:
+		return b.buildCase(d, buf, column, value)
+	}
+}
+
+// buildValues writes `UPDATE ... FROM (SELECT ... WHERE false UNION ALL VALUES ...)`.
+func (b *BatchUpdateStmt) buildValues(d Dialect, buf Buffer, column []string, value [][]interface{}) error {
+	table, qualifier := b.tableRef()
+	buf.WriteString("UPDATE ")
+	table.Build(d, buf)
+	buf.WriteString(" SET ")
+	for i, col := range column {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(d.QuoteIdent(col))
+		buf.WriteString(" = ")
+		buf.WriteString(d.QuoteIdent(batchAlias + "." + col))
+	}
+
+	name := append(append([]string{}, b.KeyColumn...), column...)
+	// untyped literals in VALUES would be text
+	buf.WriteString(" FROM (SELECT ")
+	for i, col := range name {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(d.QuoteIdent(col))
+	}
+	buf.WriteString(" FROM ")
+	table.Build(d, buf)
+	buf.WriteString(" WHERE false UNION ALL VALUES ")
+	for i, tuple := range value {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString("(")
+		for j, v := range tuple {
+			if j > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString(placeholder)
+			buf.WriteValue(v)
+		}
+		buf.WriteString(")")
+	}
+	buf.WriteString(") AS ")
+	buf.WriteString(d.QuoteIdent(batchAlias))
+	buf.WriteString(" (")
+	for i, col := range name {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(d.QuoteIdent(col))
+	}
+	buf.WriteString(")")
+
+	buf.WriteString(" WHERE ")
+	b.buildKeyMatch(d, buf, qualifier)
+	return nil
+}
+
+// buildJoin writes `UPDATE ... JOIN (SELECT ... UNION ALL ...)`.
+func (b *BatchUpdateStmt) buildJoin(d Dialect, buf Buffer, column []string, value [][]interface{}) error {
+	name := append(append([]string{}, b.KeyColumn...), column...)
+
+	table, qualifier := b.tableRef()
+	buf.WriteString("UPDATE ")
+	table.Build(d, buf)
+	buf.WriteString(" JOIN (")
+	for i, tuple := range value {
+		if i > 0 {
+			buf.WriteString(" UNION ALL ")
+		}
+		buf.WriteString("SELECT ")
+		for j, v := range tuple {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(placeholder)
+			buf.WriteValue(v)
+			if i == 0 {
+				buf.WriteString(" AS ")
+				buf.WriteString(d.QuoteIdent(name[j]))
+			}
+		}
+	}
+	buf.WriteString(") AS ")
+	buf.WriteString(d.QuoteIdent(batchAlias))
+	buf.WriteString(" ON ")
+	b.buildKeyMatch(d, buf, qualifier)
+
+	buf.WriteString(" SET ")
+	for i, col := range column {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(d.QuoteIdent(qualifier))
+		buf.WriteString(".")
+		buf.WriteString(d.QuoteIdent(col))
+		buf.WriteString(" = ")
+		buf.WriteString(d.QuoteIdent(batchAlias + "." + col))
+	}
+	return nil
+}
+
+// tableRef returns the reference to the table, and the name qualifying
+// its columns, which is the alias or the table name without the schema.
+func (b *BatchUpdateStmt) tableRef() (identRef, string) {
+	ref, ok := parseIdentRef(b.Table, true)
+	if !ok || strings.HasSuffix(ref.name, "*") {
+		return identRef{name: b.Table}, b.Table
+	}
+	if ref.alias != "" {
+		return ref, ref.alias
+	}
+	return ref, ref.name[strings.LastIndexByte(ref.name, '.')+1:]
+}
+
+// buildKeyMatch writes the condition matching the keys of the table and the rows.
+func (b *BatchUpdateStmt) buildKeyMatch(d Dialect, buf Buffer, qualifier string) {
+	for i, key := range b.KeyColumn {
+		if i > 0 {
+			buf.WriteString(" AND ")
+		}
+		buf.WriteString(d.QuoteIdent(qualifier))
+		buf.WriteString(".")
+		buf.WriteString(d.QuoteIdent(key))
+		buf.WriteString(" = ")
+		buf.WriteString(d.QuoteIdent(batchAlias + "." + key))
+	}
+}
+
+// buildCase writes `UPDATE ... SET column = CASE ... END` for each column.
+func (b *BatchUpdateStmt) buildCase(d Dialect, buf Buffer, column []string, value [][]interface{}) error {
+	nKey := len(b.KeyColumn)
+	match := make([]Builder, len(value))
+	for i, tuple := range value {
+		cond := make([]Builder, nKey)
+		for j, key := range b.KeyColumn {
+			cond[j] = eq(key, tuple[j])
+		}
+		match[i] = And(cond...)
+	}
+
+	set := make([]assignment, len(column))
+	for i, col := range column {
+		c := Case()
+		if nKey == 1 {
+			c = CaseOf(b.KeyColumn[0])
+		}
+		for j, tuple := range value {
+			if nKey == 1 {
+				c.When(tuple[0], tuple[nKey+i])
+			} else {
+				c.When(match[j], tuple[nKey+i])
+			}
+		}
+		set[i] = assignment{column: col, value: c.Else(I(col))}
+	}
+
+	table, _ := b.tableRef()
+	buf.WriteString("UPDATE ")
+	table.Build(d, buf)
+	buf.WriteString(" SET ")
+	buildAssignments(d, buf, set)
+
+	buf.WriteString(" WHERE ")
+	if nKey == 1 {
+		key := make([]interface{}, len(value))
+		for i, tuple := range value {
+			key[i] = tuple[0]
+		}
+		return Eq(b.KeyColumn[0], key).Build(d, buf)
+	}
+	return Or(match...).Build(d, buf)
+}
+
+// ToSql return the sql and args
+func (b *BatchUpdateStmt) ToSql() (string, []interface{}, error) {
+	return ToSql(b.Dialect, b)
+}
+
+// ToRawSql return the raw sql
+func (b *BatchUpdateStmt) ToRawSql() (string, error) {
+	return ToRawSql(b.Dialect, b)
+}
+
+func (b *BatchUpdateStmt) Exec() (sql.Result, error) {
+	return b.ExecContext(context.Background())
+}
+
+// ExecContext updates the rows by several statements if they exceed the limits
+// of the dialect. RowsAffected of the result is the sum of them.
+// Nothing is executed if there are no rows.
+func (b *BatchUpdateStmt) ExecContext(ctx context.Context) (sql.Result, error) {
+	column := b.columns()
+	value := b.values(column)
+	if len(value) == 0 && len(b.KeyColumn) > 0 && len(column) > 0 {
+		return &chunkResult{idErr: ErrNotSupported}, nil
+	}
+	chunk, err := chunkValues(b.Dialect, b.maxAllowedPacket, value, func([]interface{}) int {
+		return b.params(b.Dialect, column)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	runner, tx, err := chunkRunner(ctx, b.runner, b.inTx, len(chunk))
+	if err != nil {
+		return nil, err
+	}
+	if tx != nil {
+		defer tx.RollbackUnlessCommitted()
+	}
+
+	result := &chunkResult{idErr: ErrNotSupported}
+	for _, value := range chunk {
+		stmt := *b
+		stmt.Column = column
+		stmt.value = value
+		r, err := exec(ctx, runner, b.EventReceiver, &stmt, b.Dialect)
+		if err != nil {
+			return nil, err
+		}
+		n, err := r.RowsAffected()
+		if err != nil {
+			result.rowsErr = err
+		} else {
+			result.rowsAffected += n
+		}
+	}
+
+	if tx != nil {
+		err := tx.Commit()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,125 @@
+package dbx
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gokit/dbx/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchUpdate(t *testing.T) {
+	people := []*dbxPerson{
+		{Id: 1, Name: "a", Email: "a@b.c"},
+		{Id: 2, Name: "b", Email: "b@b.c"},
+	}
+	for _, test := range []struct {
+		builder Builder
+		d       Dialect
+		want    string
+	}{
+		{
+			builder: BatchUpdate("dbx_people", []string{"id"}, people),
+			d:       dialect.PostgreSQL,
+			want:    `UPDATE "dbx_people" SET "name" = "_batch"."name", "email" = "_batch"."email" FROM (SELECT "id","name","email" FROM "dbx_people" WHERE false UNION ALL VALUES (1,'a','a@b.c'), (2,'b','b@b.c')) AS "_batch" ("id","name","email") WHERE "dbx_people"."id" = "_batch"."id"`,
+		},
+		{
+			builder: BatchUpdate("dbx_people", []string{"id"}, people).Columns("name"),
+			d:       dialect.MySQL,
+			want:    "UPDATE `dbx_people` JOIN (SELECT 1 AS `id`, 'a' AS `name` UNION ALL SELECT 2, 'b') AS `_batch` ON `dbx_people`.`id` = `_batch`.`id` SET `dbx_people`.`name` = `_batch`.`name`",
+		},
+		{
+			builder: BatchUpdate("dbx_people", []string{"id"}, people),
+			d:       dialect.SQLite3,
+			want:    `UPDATE "dbx_people" SET "name" = CASE "id" WHEN 1 THEN 'a' WHEN 2 THEN 'b' ELSE "name" END, "email" = CASE "id" WHEN 1 THEN 'a@b.c' WHEN 2 THEN 'b@b.c' ELSE "email" END WHERE "id" IN (1,2)`,
+		},
+		{
+			builder: BatchUpdate("dbx_people", []string{"id", "name"}, people),
+			d:       dialect.SQLite3,
+			want:    `UPDATE "dbx_people" SET "email" = CASE WHEN ("id" = 1) AND ("name" = 'a') THEN 'a@b.c' WHEN ("id" = 2) AND ("name" = 'b') THEN 'b@b.c' ELSE "email" END WHERE (("id" = 1) AND ("name" = 'a')) OR (("id" = 2) AND ("name" = 'b'))`,
+		},
+		{
+			builder: BatchUpdate("dbx_people", []string{"id", "name"}, people),
+			d:       dialect.PostgreSQL,
+			want:    `UPDATE "dbx_people" SET "email" = "_batch"."email" FROM (SELECT "id","name","email" FROM "dbx_people" WHERE false UNION ALL VALUES (1,'a','a@b.c'), (2,'b','b@b.c')) AS "_batch" ("id","name","email") WHERE "dbx_people"."id" = "_batch"."id" AND "dbx_people"."name" = "_batch"."name"`,
+		},
+		{
+			builder: BatchUpdate("public.dbx_people", []string{"id"}, people).Columns("name"),
+			d:       dialect.PostgreSQL,
+			want:    `UPDATE "public"."dbx_people" SET "name" = "_batch"."name" FROM (SELECT "id","name" FROM "public"."dbx_people" WHERE false UNION ALL VALUES (1,'a'), (2,'b')) AS "_batch" ("id","name") WHERE "dbx_people"."id" = "_batch"."id"`,
+		},
+		{
+			builder: BatchUpdate("app.dbx_people AS p", []string{"id"}, people).Columns("name"),
+			d:       dialect.MySQL,
+			want:    "UPDATE `app`.`dbx_people` AS `p` JOIN (SELECT 1 AS `id`, 'a' AS `name` UNION ALL SELECT 2, 'b') AS `_batch` ON `p`.`id` = `_batch`.`id` SET `p`.`name` = `_batch`.`name`",
+		},
+		{
+			builder: BatchUpdate("main.dbx_people", []string{"id"}, people).Columns("name"),
+			d:       dialect.SQLite3,
+			want:    `UPDATE "main"."dbx_people" SET "name" = CASE "id" WHEN 1 THEN 'a' WHEN 2 THEN 'b' ELSE "name" END WHERE "id" IN (1,2)`,
+		},
+	} {
+		s, err := ToRawSql(test.d, test.builder)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+
+	// values are typed by the columns of the table, instead of text
+	type event struct {
+		ID        string `db:"id,pk"`
+		UpdatedAt time.Time
+	}
+	events := []event{
+		{ID: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", UpdatedAt: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)},
+	}
+	s, err := ToRawSql(dialect.PostgreSQL, BatchUpdate("events", []string{"id"}, events))
+	require.NoError(t, err)
+	require.Equal(t, `UPDATE "events" SET "updated_at" = "_batch"."updated_at" FROM (SELECT "id","updated_at" FROM "events" WHERE false UNION ALL VALUES ('6ba7b810-9dad-11d1-80b4-00c04fd430c8','2020-01-02 03:04:05.000000')) AS "_batch" ("id","updated_at") WHERE "events"."id" = "_batch"."id"`, s)
+
+	_, err = ToRawSql(dialect.PostgreSQL, BatchUpdate("dbx_people", []string{"id"}, []dbxPerson{}))
+	require.Equal(t, ErrInvalidSliceLength, err)
+	_, err = ToRawSql(dialect.PostgreSQL, BatchUpdate("dbx_people", nil, people))
+	require.Equal(t, ErrColumnNotSpecified, err)
+}
+
+func TestSQLite3BatchUpdate(t *testing.T) {
+	sess := sqlite3Session
+	reset(t, sess)
+
+	people := make([]dbxPerson, 500)
+	for i := range people {
+		people[i] = dbxPerson{Name: fmt.Sprintf("p%d", i), Email: "a@b.c"}
+	}
+	_, err := sess.InsertInto("dbx_people").Columns("name", "email").Records(people).Exec()
+	require.NoError(t, err)
+
+	for i := range people {
+		people[i].Name = fmt.Sprintf("q%d", i)
+	}
+	b := sess.BatchUpdate("dbx_people", []string{"id"}, people).Columns("name").InTransaction()
+	chunk, err := chunkValues(sess.Dialect, 0, b.values(b.columns()), func([]interface{}) int {
+		return b.params(sess.Dialect, b.columns())
+	})
+	require.NoError(t, err)
+	require.Len(t, chunk, 2)
+
+	result, err := b.Exec()
+	require.NoError(t, err)
+	n, err := result.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(500), n)
+
+	var names []string
+	_, err = sess.Select("name").From("dbx_people").OrderAsc("id").Load(&names)
+	require.NoError(t, err)
+	require.Len(t, names, 500)
+	require.Equal(t, "q0", names[0])
+	require.Equal(t, "q499", names[499])
+
+	result, err = sess.BatchUpdate("dbx_people", []string{"id"}, []dbxPerson{}).Exec()
+	require.NoError(t, err)
+	n, err = result.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+}
@@ -7,3 +7,9 @@ type orBuildFunc func(Dialect, Buffer) error
 func (b orBuildFunc) Build(d Dialect, buf Buffer) error {
 	return b(d, buf)
 }
+
+// bare wraps builder so that it is not parenthesized as a subquery
+// when it is expanded from a placeholder.
+func bare(builder Builder) Builder {
+	return BuildFunc(builder.Build)
+}
@@ -0,0 +1,79 @@
+package dbx
+
+// CaseStmt builds `CASE WHEN ... THEN ... ELSE ... END`.
+type CaseStmt struct {
+	operand interface{}
+	simple  bool
+	when    [][2]interface{}
+	elseVal interface{}
+	hasElse bool
+}
+
+// Case creates a searched CaseStmt like `CASE WHEN cond THEN value END`.
+func Case() *CaseStmt {
+	return &CaseStmt{}
+}
+
+// CaseOf creates a simple CaseStmt like `CASE operand WHEN value THEN value END`.
+// operand can be Builder, or string as a column name.
+func CaseOf(operand interface{}) *CaseStmt {
+	if column, ok := operand.(string); ok {
+		operand = I(column)
+	}
+	return &CaseStmt{
+		operand: operand,
+		simple:  true,
+	}
+}
+
+// When adds a `WHEN cond THEN value`.
+//
+// In a searched CaseStmt, cond can be Builder like Eq, or string as raw SQL.
+// In a simple CaseStmt, cond is a value compared with the operand.
+// value can be Builder like I, or any value that can be interpolated.
+func (c *CaseStmt) When(cond, value interface{}) *CaseStmt {
+	if query, ok := cond.(string); ok && !c.simple {
+		cond = Expr(query)
+	}
+	c.when = append(c.when, [2]interface{}{cond, value})
+	return c
+}
+
+// Else specifies the value when nothing matches.
+func (c *CaseStmt) Else(value interface{}) *CaseStmt {
+	c.elseVal = value
+	c.hasElse = true
+	return c
+}
+
+func (c *CaseStmt) Build(d Dialect, buf Buffer) error {
+	if len(c.when) == 0 {
+		return ErrConditionNotSpecified
+	}
+
+	buf.WriteString("CASE")
+	if c.simple {
+		buf.WriteString(" ")
+		buf.WriteString(placeholder)
+		buf.WriteValue(c.operand)
+	}
+	for _, when := range c.when {
+		buf.WriteString(" WHEN ")
+		buf.WriteString(placeholder)
+		buf.WriteString(" THEN ")
+		buf.WriteString(placeholder)
+		buf.WriteValue(when[0], when[1])
+	}
+	if c.hasElse {
+		buf.WriteString(" ELSE ")
+		buf.WriteString(placeholder)
+		buf.WriteValue(c.elseVal)
+	}
+	buf.WriteString(" END")
+	return nil
+}
+
+// As creates alias for the case expression.
+func (c *CaseStmt) As(alias string) Builder {
+	return as(c, alias)
+}
@@ -0,0 +1,71 @@
+package dbx
+
+import (
+	"testing"
+
+	"github.com/gokit/dbx/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCase(t *testing.T) {
+	for _, test := range []struct {
+		builder Builder
+		d       Dialect
+		want    string
+	}{
+		{
+			builder: Case().When(Gt("score", 90), "A").When(Gt("score", 60), "B").Else("C"),
+			d:       dialect.MySQL,
+			want:    "CASE WHEN `score` > 90 THEN 'A' WHEN `score` > 60 THEN 'B' ELSE 'C' END",
+		},
+		{
+			builder: CaseOf("status").When(1, "active").When("x'; --", I("name")),
+			d:       dialect.PostgreSQL,
+			want:    `CASE "status" WHEN 1 THEN 'active' WHEN 'x''; --' THEN "name" END`,
+		},
+		{
+			builder: Select("id", Case().When("deleted_at IS NULL", true).Else(false).As("alive")).From("users"),
+			d:       dialect.PostgreSQL,
+			want:    `SELECT "id", CASE WHEN deleted_at IS NULL THEN TRUE ELSE FALSE END AS "alive" FROM "users"`,
+		},
+		{
+			builder: Select("id").From("users").
+				OrderAscExpr(CaseOf("role").When("admin", 0).Else(1)).
+				OrderDesc("id"),
+			d:    dialect.SQLite3,
+			want: `SELECT "id" FROM "users" ORDER BY CASE "role" WHEN 'admin' THEN 0 ELSE 1 END ASC, "id" DESC`,
+		},
+		{
+			builder: Update("users").Set("level", Case().When(Gt("score", 100), 2).Else(I("level"))).Where(Eq("id", 1)),
+			d:       dialect.MySQL,
+			want:    "UPDATE `users` SET `level` = CASE WHEN `score` > 100 THEN 2 ELSE `level` END WHERE `id` = 1",
+		},
+	} {
+		s, err := ToRawSql(test.d, test.builder)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+
+	_, err := ToRawSql(dialect.MySQL, Case().Else(1))
+	require.Equal(t, ErrConditionNotSpecified, err)
+}
+
+func TestSQLite3Case(t *testing.T) {
+	sess := sqlite3Session
+	reset(t, sess)
+
+	for _, name := range []string{"a", "b", "c"} {
+		_, err := sess.InsertInto("dbx_people").Pair("name", name).Pair("email", name+"@test.com").Exec()
+		require.NoError(t, err)
+	}
+
+	var labels []string
+	_, err := sess.Select().
+		AddColumn(CaseOf("name").When("b", "first").Else("rest")).
+		From("dbx_people").
+		OrderAscExpr(CaseOf("name").When("b", 0).Else(1)).
+		OrderAsc("id").
+		Load(&labels)
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "rest", "rest"}, labels)
+}
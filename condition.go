@@ -126,17 +126,36 @@ func Lte(column string, value interface{}) Builder {
 	})
 }
 
-func buildLike(d Dialect, buf Buffer, column, pattern string, isNot bool, escape []string) error {
-	buf.WriteString(d.QuoteIdent(column))
-	if isNot {
-		buf.WriteString(" NOT LIKE ")
+func buildLike(d Dialect, buf Buffer, column, pattern string, isNot, fold bool, escape []string) error {
+	// ILIKE is only in postgres, elsewhere both sides are lowered
+	lower := fold && d.DriverName() != "postgres"
+	if lower {
+		buf.WriteString("LOWER(")
+		buf.WriteString(d.QuoteIdent(column))
+		buf.WriteString(")")
 	} else {
+		buf.WriteString(d.QuoteIdent(column))
+	}
+	if isNot {
+		buf.WriteString(" NOT")
+	}
+	switch {
+	case lower:
+		buf.WriteString(" LIKE LOWER(")
+		buf.WriteString(placeholder)
+		buf.WriteString(")")
+	case fold:
+		buf.WriteString(" ILIKE ")
+		buf.WriteString(placeholder)
+	default:
 		buf.WriteString(" LIKE ")
+		buf.WriteString(placeholder)
 	}
-	buf.WriteString(d.EncodeString(pattern))
+	buf.WriteValue(pattern)
 	if len(escape) > 0 {
 		buf.WriteString(" ESCAPE ")
-		buf.WriteString(d.EncodeString(escape[0]))
+		buf.WriteString(placeholder)
+		buf.WriteValue(escape[0])
 	}
 	return nil
 }
@@ -144,13 +163,232 @@ func buildLike(d Dialect, buf Buffer, column, pattern string, isNot bool, escape
 // Like is `LIKE`, with an optional `ESCAPE` clause
 func Like(column, value string, escape ...string) Builder {
 	return BuildFunc(func(d Dialect, buf Buffer) error {
-		return buildLike(d, buf, column, value, false, escape)
+		return buildLike(d, buf, column, value, false, false, escape)
 	})
 }
 
 // NotLike is `NOT LIKE`, with an optional `ESCAPE` clause
 func NotLike(column, value string, escape ...string) Builder {
 	return BuildFunc(func(d Dialect, buf Buffer) error {
-		return buildLike(d, buf, column, value, true, escape)
+		return buildLike(d, buf, column, value, true, false, escape)
+	})
+}
+
+// ILike is case-insensitive `LIKE`, with an optional `ESCAPE` clause.
+// It is `ILIKE` in PostgreSQL, and `LOWER(column) LIKE LOWER(value)` elsewhere.
+func ILike(column, value string, escape ...string) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		return buildLike(d, buf, column, value, false, true, escape)
+	})
+}
+
+// NotILike is case-insensitive `NOT LIKE`, with an optional `ESCAPE` clause.
+func NotILike(column, value string, escape ...string) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		return buildLike(d, buf, column, value, true, true, escape)
+	})
+}
+
+func buildBetween(d Dialect, buf Buffer, column string, isNot bool, lower, upper interface{}) error {
+	buf.WriteString(d.QuoteIdent(column))
+	if isNot {
+		buf.WriteString(" NOT")
+	}
+	buf.WriteString(" BETWEEN ")
+	buf.WriteString(placeholder)
+	buf.WriteString(" AND ")
+	buf.WriteString(placeholder)
+	buf.WriteValue(lower, upper)
+	return nil
+}
+
+// Between is `BETWEEN lower AND upper`.
+func Between(column string, lower, upper interface{}) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		return buildBetween(d, buf, column, false, lower, upper)
+	})
+}
+
+// NotBetween is `NOT BETWEEN lower AND upper`.
+func NotBetween(column string, lower, upper interface{}) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		return buildBetween(d, buf, column, true, lower, upper)
+	})
+}
+
+func buildDistinct(d Dialect, buf Buffer, column string, value interface{}, isNot bool) error {
+	switch d.DriverName() {
+	case "mysql":
+		// <=> is the null-safe equal
+		if isNot {
+			return buildCmp(d, buf, "<=>", column, value)
+		}
+		buf.WriteString("NOT (")
+		err := buildCmp(d, buf, "<=>", column, value)
+		buf.WriteString(")")
+		return err
+	case "sqlite":
+		if isNot {
+			return buildCmp(d, buf, "IS", column, value)
+		}
+		return buildCmp(d, buf, "IS NOT", column, value)
+	}
+	if isNot {
+		return buildCmp(d, buf, "IS NOT DISTINCT FROM", column, value)
+	}
+	return buildCmp(d, buf, "IS DISTINCT FROM", column, value)
+}
+
+// IsDistinctFrom is `!=` that treats NULL as a comparable value.
+// It is `IS DISTINCT FROM` in PostgreSQL, `NOT (column <=> value)` in MySQL,
+// and `IS NOT` in SQLite3.
+func IsDistinctFrom(column string, value interface{}) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		return buildDistinct(d, buf, column, value, false)
+	})
+}
+
+// IsNotDistinctFrom is `=` that treats NULL as a comparable value.
+// It is `IS NOT DISTINCT FROM` in PostgreSQL, `<=>` in MySQL,
+// and `IS` in SQLite3.
+func IsNotDistinctFrom(column string, value interface{}) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		return buildDistinct(d, buf, column, value, true)
+	})
+}
+
+func buildRegexp(d Dialect, buf Buffer, column string, pattern string, isNot bool) error {
+	if d.DriverName() == "postgres" {
+		if isNot {
+			return buildCmp(d, buf, "!~", column, pattern)
+		}
+		return buildCmp(d, buf, "~", column, pattern)
+	}
+	if isNot {
+		return buildCmp(d, buf, "NOT REGEXP", column, pattern)
+	}
+	return buildCmp(d, buf, "REGEXP", column, pattern)
+}
+
+// Regexp matches column with a regular expression.
+// It is `~` in PostgreSQL, and `REGEXP` elsewhere.
+// SQLite3 needs a regexp function to be registered by the driver.
+func Regexp(column, pattern string) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		return buildRegexp(d, buf, column, pattern, false)
+	})
+}
+
+// NotRegexp is the negation of Regexp.
+func NotRegexp(column, pattern string) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		return buildRegexp(d, buf, column, pattern, true)
+	})
+}
+
+// Not is `NOT (cond)`.
+func Not(cond Builder) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		buf.WriteString("NOT (")
+		err := cond.Build(d, buf)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(")")
+		return nil
+	})
+}
+
+// buildSubquery writes `(SELECT ...)`.
+func buildSubquery(buf Buffer, s *SelectStmt) {
+	buf.WriteString("(")
+	buf.WriteString(placeholder)
+	buf.WriteString(")")
+	buf.WriteValue(bare(s))
+}
+
+// Exists is `EXISTS (SELECT ...)`.
+func Exists(s *SelectStmt) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		buf.WriteString("EXISTS ")
+		buildSubquery(buf, s)
+		return nil
+	})
+}
+
+// NotExists is `NOT EXISTS (SELECT ...)`.
+func NotExists(s *SelectStmt) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		buf.WriteString("NOT EXISTS ")
+		buildSubquery(buf, s)
+		return nil
+	})
+}
+
+// InSubquery is `column IN (SELECT ...)`.
+func InSubquery(column string, s *SelectStmt) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		buf.WriteString(d.QuoteIdent(column))
+		buf.WriteString(" IN ")
+		buildSubquery(buf, s)
+		return nil
+	})
+}
+
+// NotInSubquery is `column NOT IN (SELECT ...)`.
+func NotInSubquery(column string, s *SelectStmt) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		buf.WriteString(d.QuoteIdent(column))
+		buf.WriteString(" NOT IN ")
+		buildSubquery(buf, s)
+		return nil
+	})
+}
+
+func isCmpOp(op string) bool {
+	switch op {
+	case "=", "!=", "<>", "<", "<=", ">", ">=":
+		return true
+	}
+	return false
+}
+
+func buildQuantified(d Dialect, buf Buffer, column, op, quantifier string, s *SelectStmt) error {
+	if !isCmpOp(op) {
+		return ErrNotSupported
+	}
+	if d.DriverName() == "sqlite" {
+		// sqlite3 has no ANY and ALL, except for their IN forms
+		switch {
+		case quantifier == "ANY" && op == "=":
+			return InSubquery(column, s).Build(d, buf)
+		case quantifier == "ALL" && (op == "!=" || op == "<>"):
+			return NotInSubquery(column, s).Build(d, buf)
+		}
+		return ErrNotSupported
+	}
+	buf.WriteString(d.QuoteIdent(column))
+	buf.WriteString(" ")
+	buf.WriteString(op)
+	buf.WriteString(" ")
+	buf.WriteString(quantifier)
+	buf.WriteString(" ")
+	buildSubquery(buf, s)
+	return nil
+}
+
+// Any is `column op ANY (SELECT ...)`, where op is a comparison like `=` or `>`.
+// SQLite3 only supports `= ANY`, which is built as `IN`.
+func Any(column, op string, s *SelectStmt) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		return buildQuantified(d, buf, column, op, "ANY", s)
+	})
+}
+
+// All is `column op ALL (SELECT ...)`, where op is a comparison like `=` or `>`.
+// SQLite3 only supports `!= ALL`, which is built as `NOT IN`.
+func All(column, op string, s *SelectStmt) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		return buildQuantified(d, buf, column, op, "ALL", s)
 	})
 }
@@ -63,35 +63,50 @@ func TestCondition(t *testing.T) {
 			query: "(`a` < ?) AND ((`b` > ?) OR (`c` != ?))",
 			value: []interface{}{1, 2, 3},
 		},
+		{
+			cond:  Between("a", 1, 2),
+			query: "`a` BETWEEN ? AND ?",
+			value: []interface{}{1, 2},
+		},
+		{
+			cond:  NotBetween("a", 1, 2),
+			query: "`a` NOT BETWEEN ? AND ?",
+			value: []interface{}{1, 2},
+		},
+		{
+			cond:  Not(Eq("a", 1)),
+			query: "NOT (`a` = ?)",
+			value: []interface{}{1},
+		},
 		{
 			cond:  Like("a", "%BLAH%", "#"),
-			query: "`a` LIKE '%BLAH%' ESCAPE '#'",
-			value: nil,
+			query: "`a` LIKE ? ESCAPE ?",
+			value: []interface{}{"%BLAH%", "#"},
 		},
 		{
 			cond:  Like("a", "%50#%%", "#"),
-			query: "`a` LIKE '%50#%%' ESCAPE '#'",
-			value: nil,
+			query: "`a` LIKE ? ESCAPE ?",
+			value: []interface{}{"%50#%%", "#"},
 		},
 		{
 			cond:  NotLike("a", "%BLAH%", "#"),
-			query: "`a` NOT LIKE '%BLAH%' ESCAPE '#'",
-			value: nil,
+			query: "`a` NOT LIKE ? ESCAPE ?",
+			value: []interface{}{"%BLAH%", "#"},
 		},
 		{
 			cond:  NotLike("a", "%50#%%", "#"),
-			query: "`a` NOT LIKE '%50#%%' ESCAPE '#'",
-			value: nil,
+			query: "`a` NOT LIKE ? ESCAPE ?",
+			value: []interface{}{"%50#%%", "#"},
 		},
 		{
 			cond:  Like("a", "_x_"),
-			query: "`a` LIKE '_x_'",
-			value: nil,
+			query: "`a` LIKE ?",
+			value: []interface{}{"_x_"},
 		},
 		{
 			cond:  NotLike("a", "_x_"),
-			query: "`a` NOT LIKE '_x_'",
-			value: nil,
+			query: "`a` NOT LIKE ?",
+			value: []interface{}{"_x_"},
 		},
 	} {
 		buf := NewBuffer()
@@ -101,3 +116,145 @@ func TestCondition(t *testing.T) {
 		require.Equal(t, test.value, buf.Value())
 	}
 }
+
+func TestSubqueryCondition(t *testing.T) {
+	sub := Select("user_id").From("orders").Where(Gt("total", 100))
+	for _, test := range []struct {
+		cond Builder
+		d    Dialect
+		want string
+	}{
+		{
+			cond: Exists(Select(Expr("1")).From("orders").Where("orders.user_id = users.id")),
+			d:    dialect.MySQL,
+			want: "EXISTS (SELECT 1 FROM `orders` WHERE orders.user_id = users.id)",
+		},
+		{
+			cond: NotExists(sub),
+			d:    dialect.SQLite3,
+			want: `NOT EXISTS (SELECT "user_id" FROM "orders" WHERE "total" > 100)`,
+		},
+		{
+			cond: InSubquery("id", sub),
+			d:    dialect.PostgreSQL,
+			want: `"id" IN (SELECT "user_id" FROM "orders" WHERE "total" > 100)`,
+		},
+		{
+			cond: NotInSubquery("id", sub),
+			d:    dialect.MySQL,
+			want: "`id` NOT IN (SELECT `user_id` FROM `orders` WHERE `total` > 100)",
+		},
+		{
+			cond: Any("id", "=", sub),
+			d:    dialect.PostgreSQL,
+			want: `"id" = ANY (SELECT "user_id" FROM "orders" WHERE "total" > 100)`,
+		},
+		{
+			cond: All("score", ">", sub),
+			d:    dialect.MySQL,
+			want: "`score` > ALL (SELECT `user_id` FROM `orders` WHERE `total` > 100)",
+		},
+		{
+			cond: Any("id", "=", sub),
+			d:    dialect.SQLite3,
+			want: `"id" IN (SELECT "user_id" FROM "orders" WHERE "total" > 100)`,
+		},
+		{
+			cond: And(Eq("a", 1), Exists(sub)),
+			d:    dialect.PostgreSQL,
+			want: `("a" = 1) AND (EXISTS (SELECT "user_id" FROM "orders" WHERE "total" > 100))`,
+		},
+	} {
+		s, err := ToRawSql(test.d, test.cond)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+
+	_, err := ToRawSql(dialect.SQLite3, All("id", ">", sub))
+	require.Equal(t, ErrNotSupported, err)
+
+	_, err = ToRawSql(dialect.PostgreSQL, Any("id", "= 1 OR 1 =", sub))
+	require.Equal(t, ErrNotSupported, err)
+}
+
+func TestSubqueryConditionPlaceholder(t *testing.T) {
+	i := interpolator{
+		Buffer:       NewBuffer(),
+		Dialect:      dialect.PostgreSQL,
+		IgnoreBinary: true,
+	}
+	builder := Select("id").From("users").
+		Where("token = ?", []byte{1}).
+		AndWhere(Exists(Select(Expr("1")).From("orders").Where("hash = ?", []byte{2})))
+	err := i.encodePlaceholder(builder, true)
+	require.NoError(t, err)
+	require.Equal(t, `SELECT "id" FROM "users" WHERE (token = $1) AND (EXISTS (SELECT 1 FROM "orders" WHERE hash = $2))`, i.String())
+}
+
+func TestDialectCondition(t *testing.T) {
+	for _, test := range []struct {
+		cond Builder
+		d    Dialect
+		want string
+	}{
+		{
+			cond: ILike("a", "%x%"),
+			d:    dialect.PostgreSQL,
+			want: `"a" ILIKE '%x%'`,
+		},
+		{
+			cond: ILike("a", "%x%", "#"),
+			d:    dialect.MySQL,
+			want: "LOWER(`a`) LIKE LOWER('%x%') ESCAPE '#'",
+		},
+		{
+			cond: NotILike("a", "%x%"),
+			d:    dialect.SQLite3,
+			want: `LOWER("a") NOT LIKE LOWER('%x%')`,
+		},
+		{
+			cond: IsDistinctFrom("a", nil),
+			d:    dialect.PostgreSQL,
+			want: `"a" IS DISTINCT FROM NULL`,
+		},
+		{
+			cond: IsDistinctFrom("a", 1),
+			d:    dialect.MySQL,
+			want: "NOT (`a` <=> 1)",
+		},
+		{
+			cond: IsNotDistinctFrom("a", 1),
+			d:    dialect.MySQL,
+			want: "`a` <=> 1",
+		},
+		{
+			cond: IsDistinctFrom("a", 1),
+			d:    dialect.SQLite3,
+			want: `"a" IS NOT 1`,
+		},
+		{
+			cond: IsNotDistinctFrom("a", 1),
+			d:    dialect.SQLite3,
+			want: `"a" IS 1`,
+		},
+		{
+			cond: Regexp("a", "^x"),
+			d:    dialect.PostgreSQL,
+			want: `"a" ~ '^x'`,
+		},
+		{
+			cond: NotRegexp("a", "^x"),
+			d:    dialect.MySQL,
+			want: "`a` NOT REGEXP '^x'",
+		},
+		{
+			cond: Like("a", "it's%"),
+			d:    dialect.SQLite3,
+			want: `"a" LIKE 'it''s%'`,
+		},
+	} {
+		s, err := ToRawSql(test.d, test.cond)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+}
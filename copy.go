@@ -0,0 +1,223 @@
+package dbx
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// RowSource iterates the rows copied by CopyFrom.
+type RowSource interface {
+	// Next advances to the next row, and returns false if there is none.
+	Next() bool
+	// Values returns the values of the current row in the order of columns.
+	Values() ([]interface{}, error)
+	// Err returns the error that stopped Next, if any.
+	Err() error
+}
+
+// nextRow returns the values of the next row, or false after the last row.
+type nextRow func(ctx context.Context) ([]interface{}, bool, error)
+
+// rowValues returns the values for column from a struct, or a []interface{} as it is.
+func rowValues(v reflect.Value, column []string) ([]interface{}, error) {
+	if value, ok := v.Interface().([]interface{}); ok {
+		return value, nil
+	}
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct {
+		return nil, ErrNotSupported
+	}
+	found := make([]interface{}, len(column))
+	newTagStore().findValueByName(v, column, found, false)
+	for i, f := range found {
+		if f != nil {
+			found[i] = f.(reflect.Value).Interface()
+		}
+	}
+	return found, nil
+}
+
+// rowSource turns source into nextRow.
+func rowSource(source interface{}, column []string) (nextRow, error) {
+	if src, ok := source.(RowSource); ok {
+		return func(context.Context) ([]interface{}, bool, error) {
+			if !src.Next() {
+				return nil, false, src.Err()
+			}
+			value, err := src.Values()
+			return value, err == nil, err
+		}, nil
+	}
+
+	v := reflect.ValueOf(source)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		i := 0
+		return func(context.Context) ([]interface{}, bool, error) {
+			if i >= v.Len() {
+				return nil, false, nil
+			}
+			value, err := rowValues(v.Index(i), column)
+			i++
+			return value, err == nil, err
+		}, nil
+	case reflect.Chan:
+		return func(ctx context.Context) ([]interface{}, bool, error) {
+			chosen, elem, ok := reflect.Select([]reflect.SelectCase{
+				{Dir: reflect.SelectRecv, Chan: v},
+				{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+			})
+			if chosen == 1 {
+				return nil, false, ctx.Err()
+			}
+			if !ok {
+				return nil, false, nil
+			}
+			value, err := rowValues(elem, column)
+			return value, err == nil, err
+		}, nil
+	}
+	return nil, ErrNotSupported
+}
+
+// CopyFrom copies rows from source into table in a transaction.
+//
+// source can be a slice of structs, a channel of structs, or RowSource.
+// Struct fields are matched with column like in Record, and a []interface{}
+// in a slice or a channel is taken as the values of a row.
+//
+// It uses `COPY FROM` with lib/pq, and multiple-row inserts elsewhere,
+// including other PostgreSQL drivers like pgx.
+func (sess *Session) CopyFrom(table string, column []string, source interface{}) (int64, error) {
+	return sess.CopyFromContext(context.Background(), table, column, source)
+}
+
+func (sess *Session) CopyFromContext(ctx context.Context, table string, column []string, source interface{}) (int64, error) {
+	tx, err := sess.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.RollbackUnlessCommitted()
+
+	n, err := tx.CopyFromContext(ctx, table, column, source)
+	if err != nil {
+		return 0, err
+	}
+	return n, tx.Commit()
+}
+
+// CopyFrom copies rows from source into table like Session.CopyFrom.
+func (tx *Tx) CopyFrom(table string, column []string, source interface{}) (int64, error) {
+	return tx.CopyFromContext(context.Background(), table, column, source)
+}
+
+func (tx *Tx) CopyFromContext(ctx context.Context, table string, column []string, source interface{}) (int64, error) {
+	if len(column) == 0 {
+		return 0, ErrColumnNotSpecified
+	}
+	next, err := rowSource(source, column)
+	if err != nil {
+		return 0, err
+	}
+
+	timeout := tx.GetTimeout()
+	if timeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	startTime := time.Now()
+	defer func() {
+		tx.TimingKv("dbx.copy", time.Since(startTime).Nanoseconds(), kvs{
+			"table": table,
+		})
+	}()
+
+	var n int64
+	// COPY FROM STDIN is prepared as a special statement only by lib/pq
+	if _, ok := tx.driver.(*pq.Driver); ok {
+		n, err = tx.copyIn(ctx, table, column, next)
+	} else {
+		n, err = tx.copyByInsert(ctx, table, column, next)
+	}
+	if err != nil {
+		return 0, tx.EventErrKv("dbx.copy", err, kvs{
+			"table": table,
+		})
+	}
+	return n, nil
+}
+
+func (tx *Tx) copyIn(ctx context.Context, table string, column []string, next nextRow) (int64, error) {
+	query := pq.CopyIn(table, column...)
+	if i := strings.IndexByte(table, '.'); i != -1 {
+		query = pq.CopyInSchema(table[:i], table[i+1:], column...)
+	}
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	var n int64
+	for {
+		value, ok, err := next(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			break
+		}
+		_, err = stmt.ExecContext(ctx, value...)
+		if err != nil {
+			return 0, err
+		}
+		n++
+	}
+	// flush the buffered rows
+	_, err = stmt.ExecContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func (tx *Tx) copyByInsert(ctx context.Context, table string, column []string, next nextRow) (int64, error) {
+	batch := maxParams(tx.Dialect) / len(column)
+	if limit := maxRows(tx.Dialect); limit > 0 && batch > limit {
+		batch = limit
+	}
+	if batch < 1 {
+		batch = 1
+	}
+
+	var n int64
+	for done := false; !done; {
+		b := tx.InsertInto(table).Columns(column...)
+		for len(b.Value) < batch {
+			value, ok, err := next(ctx)
+			if err != nil {
+				return 0, err
+			}
+			if !ok {
+				done = true
+				break
+			}
+			b.Values(value...)
+		}
+		if len(b.Value) == 0 {
+			break
+		}
+		_, err := b.ExecContext(ctx)
+		if err != nil {
+			return 0, err
+		}
+		n += int64(len(b.Value))
+	}
+	return n, nil
+}
@@ -0,0 +1,132 @@
+package dbx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/gokit/dbx/dialect"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+type sliceSource struct {
+	row [][]interface{}
+	i   int
+}
+
+func (s *sliceSource) Next() bool {
+	s.i++
+	return s.i <= len(s.row)
+}
+
+func (s *sliceSource) Values() ([]interface{}, error) {
+	return s.row[s.i-1], nil
+}
+
+func (s *sliceSource) Err() error {
+	return nil
+}
+
+// pqConnector connects to a sqlmock database as if it is lib/pq.
+type pqConnector struct {
+	mock driver.Driver
+	dsn  string
+}
+
+func (c pqConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.mock.Open(c.dsn)
+}
+
+func (c pqConnector) Driver() driver.Driver {
+	return &pq.Driver{}
+}
+
+func TestPostgresCopyFrom(t *testing.T) {
+	mockDB, mock, err := sqlmock.NewWithDSN("TestPostgresCopyFrom")
+	require.NoError(t, err)
+
+	conn := &Connection{
+		DB:            sql.OpenDB(pqConnector{mock: mockDB.Driver(), dsn: "TestPostgresCopyFrom"}),
+		EventReceiver: &NullEventReceiver{},
+		Dialect:       dialect.PostgreSQL,
+	}
+	sess := conn.NewSession(nil)
+
+	mock.ExpectBegin()
+	prep := mock.ExpectPrepare(regexp.QuoteMeta(`COPY "public"."dbx_people" ("name", "email") FROM STDIN`))
+	prep.ExpectExec().WithArgs("a", "a@test.com").WillReturnResult(sqlmock.NewResult(0, 0))
+	prep.ExpectExec().WithArgs("b", "b@test.com").WillReturnResult(sqlmock.NewResult(0, 0))
+	prep.ExpectExec().WithArgs().WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	people := []dbxPerson{{Name: "a", Email: "a@test.com"}, {Name: "b", Email: "b@test.com"}}
+	n, err := sess.CopyFrom("public.dbx_people", []string{"name", "email"}, people)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPgxCopyFrom(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	// the dialect of pgx is PostgreSQL, but COPY only works with lib/pq
+	conn := &Connection{
+		DB:            db,
+		EventReceiver: &NullEventReceiver{},
+		Dialect:       dialect.PostgreSQL,
+	}
+	sess := conn.NewSession(nil)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "public"."dbx_people" ("name","email") VALUES ('a','a@test.com'), ('b','b@test.com')`)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	people := []dbxPerson{{Name: "a", Email: "a@test.com"}, {Name: "b", Email: "b@test.com"}}
+	n, err := sess.CopyFrom("public.dbx_people", []string{"name", "email"}, people)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLite3CopyFrom(t *testing.T) {
+	sess := sqlite3Session
+	reset(t, sess)
+
+	column := []string{"name", "email"}
+	people := make([]*dbxPerson, 1200)
+	for i := range people {
+		people[i] = &dbxPerson{Name: "slice", Email: "a@test.com"}
+	}
+	n, err := sess.CopyFrom("dbx_people", column, people)
+	require.NoError(t, err)
+	require.Equal(t, int64(1200), n)
+
+	ch := make(chan dbxPerson)
+	go func() {
+		for i := 0; i < 3; i++ {
+			ch <- dbxPerson{Name: "chan", Email: "b@test.com"}
+		}
+		close(ch)
+	}()
+	n, err = sess.CopyFrom("dbx_people", column, ch)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+
+	tx, err := sess.Begin()
+	require.NoError(t, err)
+	n, err = tx.CopyFrom("dbx_people", column, &sliceSource{row: [][]interface{}{{"source", "c@test.com"}}})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+	require.NoError(t, tx.Commit())
+
+	var count []int
+	_, err = sess.Select("count(*)").From("dbx_people").GroupBy("name").OrderBy("name").Load(&count)
+	require.NoError(t, err)
+	require.Equal(t, []int{3, 1200, 1}, count)
+}
@@ -4,34 +4,70 @@ package dbx
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/gokit/dbx/dialect"
 )
 
+var (
+	dialectsMu sync.RWMutex
+	dialects   = map[string]Dialect{
+		"mysql":     dialect.MySQL,
+		"postgres":  dialect.PostgreSQL,
+		"pgx":       dialect.PostgreSQL,
+		"sqlite3":   dialect.SQLite3,
+		"sqlite":    dialect.SQLite3,
+		"sqlserver": dialect.MSSQL,
+		"mssql":     dialect.MSSQL,
+	}
+)
+
+// RegisterDialect makes a Dialect available to Open by the name of a driver
+// registered to database/sql, e.g. a wrapped instrumented driver.
+// It replaces the Dialect registered with the same name.
+func RegisterDialect(driverName string, d Dialect) {
+	if d == nil {
+		panic("dbx: RegisterDialect dialect is nil")
+	}
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+	dialects[driverName] = d
+}
+
 // Open creates a Connection.
+// The Dialect is the one registered with the driver name by RegisterDialect.
 // log can be nil to ignore logging.
 func Open(driver, dsn string, log EventReceiver) (*Connection, error) {
-	if log == nil {
-		log = nullReceiver
+	dialectsMu.RLock()
+	d, ok := dialects[driver]
+	dialectsMu.RUnlock()
+	if !ok {
+		return nil, ErrNotSupported
 	}
 	conn, err := sql.Open(driver, dsn)
 	if err != nil {
 		return nil, err
 	}
-	var d Dialect
-	switch driver {
-	case "mysql":
-		d = dialect.MySQL
-	case "postgres", "pgx":
-		d = dialect.PostgreSQL
-	case "sqlite3":
-		d = dialect.SQLite3
-	default:
-		return nil, ErrNotSupported
+	return NewConnection(conn, d, log), nil
+}
+
+// OpenConnector creates a Connection using a driver.Connector,
+// like sql.OpenDB, without logging.
+func OpenConnector(c driver.Connector, d Dialect) *Connection {
+	return NewConnection(sql.OpenDB(c), d, nil)
+}
+
+// NewConnection creates a Connection from a sql.DB,
+// so that the pool can be configured and shared outside of dbx.
+// log can be nil to ignore logging.
+func NewConnection(db *sql.DB, d Dialect, log EventReceiver) *Connection {
+	if log == nil {
+		log = nullReceiver
 	}
-	return &Connection{DB: conn, EventReceiver: log, Dialect: d}, nil
+	return &Connection{DB: db, EventReceiver: log, Dialect: d}
 }
 
 const (
@@ -44,6 +80,12 @@ type Connection struct {
 	*sql.DB
 	Dialect
 	EventReceiver
+
+	prepared         bool
+	stmtCacheSize    int
+	stmtMu           sync.Mutex
+	stmts            *stmtCache
+	maxAllowedPacket int
 }
 
 // Session represents a business unit of execution.
@@ -59,6 +101,9 @@ type Session struct {
 	*Connection
 	EventReceiver
 	Timeout time.Duration
+
+	prepared         bool
+	maxAllowedPacket int
 }
 
 // GetTimeout returns current timeout enforced in session.
@@ -72,7 +117,16 @@ func (conn *Connection) NewSession(log EventReceiver) *Session {
 	if log == nil {
 		log = conn.EventReceiver // Use parent instrumentation
 	}
-	return &Session{Connection: conn, EventReceiver: log}
+	conn.stmtMu.Lock()
+	prepared := conn.prepared
+	maxAllowedPacket := conn.maxAllowedPacket
+	conn.stmtMu.Unlock()
+	return &Session{
+		Connection:       conn,
+		EventReceiver:    log,
+		prepared:         prepared,
+		maxAllowedPacket: maxAllowedPacket,
+	}
 }
 
 // Ensure that tx and session are session runner
@@ -111,10 +165,12 @@ func exec(ctx context.Context, runner runner, log EventReceiver, builder Builder
 		defer cancel()
 	}
 
+	stmts := stmtCacheOf(runner)
 	i := interpolator{
 		Buffer:       NewBuffer(),
 		Dialect:      d,
 		IgnoreBinary: true,
+		BindAll:      stmts != nil,
 	}
 	err := i.encodePlaceholder(builder, true)
 	query, value := i.String(), i.Value()
@@ -138,7 +194,12 @@ func exec(ctx context.Context, runner runner, log EventReceiver, builder Builder
 		defer traceImpl.SpanFinish(ctx)
 	}
 
-	result, err := runner.ExecContext(ctx, query, value...)
+	var result sql.Result
+	if stmts != nil {
+		result, err = stmts.exec(ctx, query, value)
+	} else {
+		result, err = runner.ExecContext(ctx, query, value...)
+	}
 	if err != nil {
 		if hasTracingImpl {
 			traceImpl.SpanError(ctx, err)
@@ -154,10 +215,12 @@ func queryRows(ctx context.Context, runner runner, log EventReceiver, builder Bu
 	// discard the timeout set in the runner, the context should not be canceled
 	// implicitly here but explicitly by the caller since the returned *sql.Rows
 	// may still listening to the context
+	stmts := stmtCacheOf(runner)
 	i := interpolator{
 		Buffer:       NewBuffer(),
 		Dialect:      d,
 		IgnoreBinary: true,
+		BindAll:      stmts != nil,
 	}
 	err := i.encodePlaceholder(builder, true)
 	query, value := i.String(), i.Value()
@@ -181,7 +244,12 @@ func queryRows(ctx context.Context, runner runner, log EventReceiver, builder Bu
 		defer traceImpl.SpanFinish(ctx)
 	}
 
-	rows, err := runner.QueryContext(ctx, query, value...)
+	var rows *sql.Rows
+	if stmts != nil {
+		rows, err = stmts.query(ctx, query, value)
+	} else {
+		rows, err = runner.QueryContext(ctx, query, value...)
+	}
 	if err != nil {
 		if hasTracingImpl {
 			traceImpl.SpanError(ctx, err)
@@ -2,6 +2,8 @@ package dbx
 
 import (
 	"context"
+	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"os"
 	"testing"
@@ -10,7 +12,7 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/gokit/dbx/dialect"
 	_ "github.com/lib/pq"
-	_ "github.com/mattn/go-sqlite3"
+	sqlite3 "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/require"
 )
 
@@ -210,3 +212,65 @@ func TestTimeout(t *testing.T) {
 		require.Equal(t, context.DeadlineExceeded, err)
 	}
 }
+
+type dsnConnector struct {
+	driver driver.Driver
+	dsn    string
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+func TestRegisterDialect(t *testing.T) {
+	_, err := Open("dbx_sqlite3", sqlite3DSN, nil)
+	require.Equal(t, ErrNotSupported, err)
+
+	sql.Register("dbx_sqlite3", &sqlite3.SQLiteDriver{})
+	RegisterDialect("dbx_sqlite3", dialect.SQLite3)
+
+	conn, err := Open("dbx_sqlite3", sqlite3DSN, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Equal(t, dialect.SQLite3, conn.Dialect)
+
+	var n int
+	err = conn.NewSession(nil).SelectBySql("SELECT 1").LoadOne(&n)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+}
+
+func TestNewConnection(t *testing.T) {
+	db, err := sql.Open("sqlite3", sqlite3DSN)
+	require.NoError(t, err)
+
+	conn := NewConnection(db, dialect.SQLite3, nil)
+	defer conn.Close()
+	require.Equal(t, db, conn.DB)
+	require.Equal(t, nullReceiver, conn.EventReceiver)
+
+	var n int
+	err = conn.NewSession(nil).SelectBySql("SELECT 1").LoadOne(&n)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+}
+
+func TestOpenConnector(t *testing.T) {
+	conn := OpenConnector(dsnConnector{driver: &sqlite3.SQLiteDriver{}, dsn: sqlite3DSN}, dialect.SQLite3)
+	defer conn.Close()
+	conn.SetMaxOpenConns(1)
+
+	sess := conn.NewSession(nil)
+	reset(t, sess)
+	_, err := sess.InsertInto("dbx_people").Columns("name", "email").Values("a", "a@test.com").Exec()
+	require.NoError(t, err)
+
+	var name string
+	err = sess.Select("name").From("dbx_people").LoadOne(&name)
+	require.NoError(t, err)
+	require.Equal(t, "a", name)
+}
@@ -14,10 +14,14 @@ type DeleteStmt struct {
 
 	raw
 
-	Table      string
-	WhereCond  []Builder
-	LimitCount int64
-
+	Table        string
+	UsingTable   interface{}
+	WhereCond    []Builder
+	ReturnColumn []string
+	LimitCount   int64
+
+	joins    []joinClause
+	with     withClause
 	comments Comments
 }
 
@@ -37,17 +41,103 @@ func (b *DeleteStmt) Build(d Dialect, buf Buffer) error {
 		return err
 	}
 
-	buf.WriteString("DELETE FROM ")
-	buf.WriteString(d.QuoteIdent(b.Table))
+	err = b.with.Build(d, buf)
+	if err != nil {
+		return err
+	}
+
+	whereCond := b.WhereCond
+	isMSSQL := d.DriverName() == "mssql"
+	if isMSSQL {
+		// SQL Server has TOP instead of LIMIT, OUTPUT instead of RETURNING,
+		// and lists the other tables in a second FROM.
+		buf.WriteString("DELETE ")
+		if b.LimitCount >= 0 {
+			buildTop(buf, b.LimitCount)
+		}
+		buf.WriteString("FROM ")
+		buf.WriteString(d.QuoteIdent(b.Table))
+		if len(b.ReturnColumn) > 0 {
+			buildOutput(d, buf, "DELETED", b.ReturnColumn)
+		}
+		if b.UsingTable != nil || len(b.joins) > 0 {
+			buf.WriteString(" FROM ")
+			on, err := buildJoinedTables(d, buf, b.UsingTable, b.joins)
+			if err != nil {
+				return err
+			}
+			whereCond = mergeCond(on, whereCond)
+		}
+	} else if b.UsingTable == nil && len(b.joins) == 0 {
+		buf.WriteString("DELETE FROM ")
+		buf.WriteString(d.QuoteIdent(b.Table))
+	} else {
+		switch d.DriverName() {
+		case "mysql":
+			// DELETE t FROM t, other JOIN ...
+			buf.WriteString("DELETE ")
+			buf.WriteString(d.QuoteIdent(b.Table))
+			buf.WriteString(" FROM ")
+			buf.WriteString(d.QuoteIdent(b.Table))
+			if b.UsingTable != nil {
+				buf.WriteString(", ")
+				buildTable(d, buf, b.UsingTable)
+			}
+			for _, j := range b.joins {
+				err := j.Build(d, buf)
+				if err != nil {
+					return err
+				}
+			}
+		case "sqlite":
+			// sqlite3 has no multi-table DELETE,
+			// so the other tables are checked in a correlated EXISTS.
+			buf.WriteString("DELETE FROM ")
+			buf.WriteString(d.QuoteIdent(b.Table))
+			buf.WriteString(" WHERE EXISTS (SELECT 1 FROM ")
+			on, err := buildJoinedTables(d, buf, b.UsingTable, b.joins)
+			if err != nil {
+				return err
+			}
+			whereCond = mergeCond(on, whereCond)
+			if len(whereCond) > 0 {
+				buf.WriteString(" WHERE ")
+				err := logicCond(whereCond...).Build(d, buf)
+				if err != nil {
+					return err
+				}
+			}
+			buf.WriteString(")")
+			whereCond = nil
+		default:
+			buf.WriteString("DELETE FROM ")
+			buf.WriteString(d.QuoteIdent(b.Table))
+			buf.WriteString(" USING ")
+			on, err := buildJoinedTables(d, buf, b.UsingTable, b.joins)
+			if err != nil {
+				return err
+			}
+			whereCond = mergeCond(on, whereCond)
+		}
+	}
 
-	if len(b.WhereCond) > 0 {
+	if len(whereCond) > 0 {
 		buf.WriteString(" WHERE ")
-		err := logicCond(b.WhereCond...).Build(d, buf)
+		err := logicCond(whereCond...).Build(d, buf)
 		if err != nil {
 			return err
 		}
 	}
-	if b.LimitCount >= 0 {
+	if len(b.ReturnColumn) > 0 && !isMSSQL {
+		buf.WriteString(" RETURNING ")
+		for i, col := range b.ReturnColumn {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString(d.QuoteIdent(col))
+		}
+	}
+	if b.LimitCount >= 0 && !isMSSQL {
 		buf.WriteString(" LIMIT ")
 		buf.WriteString(strconv.FormatInt(b.LimitCount, 10))
 	}
@@ -125,6 +215,13 @@ func (b *DeleteStmt) Where(query interface{}, value ...interface{}) *DeleteStmt
 	return b
 }
 
+// Returning specifies the returning columns for postgres.
+// In SQL Server, it is `OUTPUT DELETED`, which fails on tables with triggers.
+func (b *DeleteStmt) Returning(column ...string) *DeleteStmt {
+	b.ReturnColumn = column
+	return b
+}
+
 func (b *DeleteStmt) Limit(n uint64) *DeleteStmt {
 	b.LimitCount = int64(n)
 	return b
@@ -142,3 +239,12 @@ func (b *DeleteStmt) Exec() (sql.Result, error) {
 func (b *DeleteStmt) ExecContext(ctx context.Context) (sql.Result, error) {
 	return exec(ctx, b.runner, b.EventReceiver, b, b.Dialect)
 }
+
+func (b *DeleteStmt) LoadContext(ctx context.Context, value interface{}) error {
+	_, err := query(ctx, b.runner, b.EventReceiver, b, b.Dialect, value)
+	return err
+}
+
+func (b *DeleteStmt) Load(value interface{}) error {
+	return b.LoadContext(context.Background(), value)
+}
@@ -32,6 +32,41 @@ func (b *DeleteStmt) OrWhere(query interface{}, value ...interface{}) *DeleteStm
 	return b
 }
 
+// Using adds another table to check in WHERE.
+// table can be Builder like SelectStmt, or string.
+//
+// It is `DELETE FROM ... USING table` in PostgreSQL,
+// and `DELETE t FROM t, table` in MySQL.
+// SQLite3 checks the table in a correlated `EXISTS` subquery.
+func (b *DeleteStmt) Using(table interface{}) *DeleteStmt {
+	b.UsingTable = table
+	return b
+}
+
+// Join add inner-join.
+// on can be Builder or string.
+//
+// In PostgreSQL and SQLite3, if Using is not set, the first joined table
+// is moved to USING, and on is added to WHERE.
+func (b *DeleteStmt) Join(table, on interface{}) *DeleteStmt {
+	b.joins = append(b.joins, joinClause{t: inner, table: table, on: on})
+	return b
+}
+
+// With adds a common table expression named name.
+// builder can be SelectStmt, or any other Builder.
+func (b *DeleteStmt) With(name string, builder Builder) *DeleteStmt {
+	b.with = b.with.add(name, nil, builder, false)
+	return b
+}
+
+// WithRecursive adds a recursive common table expression named name.
+// column lists the column names of the table and can be empty.
+func (b *DeleteStmt) WithRecursive(name string, column []string, builder Builder) *DeleteStmt {
+	b.with = b.with.add(name, column, builder, true)
+	return b
+}
+
 // ToSql return the sql and args
 func (b *DeleteStmt) ToSql() (string, []interface{}, error) {
 	return ToSql(b.Dialect, b)
@@ -1,8 +1,10 @@
 package dbx
 
 import (
+	"regexp"
 	"testing"
 
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/gokit/dbx/dialect"
 	"github.com/stretchr/testify/require"
 )
@@ -22,3 +24,75 @@ func BenchmarkDeleteSQL(b *testing.B) {
 		DeleteFrom("table").Where(Eq("a", 1)).Build(dialect.MySQL, buf)
 	}
 }
+
+func TestDeleteWith(t *testing.T) {
+	builder := DeleteFrom("orders").
+		With("stale", Select("id").From("orders").Where(Lt("updated_at", "2020-01-01"))).
+		Where(Expr("id IN (SELECT id FROM stale)"))
+	s, err := ToRawSql(dialect.MySQL, builder)
+	require.NoError(t, err)
+	require.Equal(t, "WITH `stale` AS (SELECT `id` FROM `orders` WHERE `updated_at` < '2020-01-01') DELETE FROM `orders` WHERE id IN (SELECT id FROM stale)", s)
+}
+
+func TestDeleteJoin(t *testing.T) {
+	for _, test := range []struct {
+		builder Builder
+		d       Dialect
+		want    string
+	}{
+		{
+			builder: DeleteFrom("orders").
+				Join("users", "orders.user_id = users.id").
+				Where(Eq("users.banned", true)),
+			d:    dialect.MySQL,
+			want: "DELETE `orders` FROM `orders` JOIN `users` ON orders.user_id = users.id WHERE `users`.`banned` = 1",
+		},
+		{
+			builder: DeleteFrom("orders").
+				Join("users", "orders.user_id = users.id").
+				Where(Eq("users.banned", true)).
+				Returning("id"),
+			d:    dialect.PostgreSQL,
+			want: `DELETE FROM "orders" USING "users" WHERE (orders.user_id = users.id) AND ("users"."banned" = TRUE) RETURNING "id"`,
+		},
+		{
+			builder: DeleteFrom("orders").
+				Using("users").
+				Where("orders.user_id = users.id"),
+			d:    dialect.PostgreSQL,
+			want: `DELETE FROM "orders" USING "users" WHERE orders.user_id = users.id`,
+		},
+		{
+			builder: DeleteFrom("orders").
+				Join("users", "orders.user_id = users.id").
+				Where(Eq("users.banned", true)),
+			d:    dialect.SQLite3,
+			want: `DELETE FROM "orders" WHERE EXISTS (SELECT 1 FROM "users" WHERE (orders.user_id = users.id) AND ("users"."banned" = 1))`,
+		},
+	} {
+		s, err := ToRawSql(test.d, test.builder)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+}
+
+func TestDeleteReturningLoad(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	conn := &Connection{
+		DB:            db,
+		EventReceiver: &NullEventReceiver{},
+		Dialect:       dialect.PostgreSQL,
+	}
+	sess := conn.NewSession(nil)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`DELETE FROM "dbx_people" WHERE "name" = 'test1' RETURNING "id","name","email"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).AddRow(1, "test1", "test1@test.com"))
+
+	var people []dbxPerson
+	err = sess.DeleteFrom("dbx_people").Where(Eq("name", "test1")).Returning("id", "name", "email").Load(&people)
+	require.NoError(t, err)
+	require.Equal(t, []dbxPerson{{Id: 1, Name: "test1", Email: "test1@test.com"}}, people)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -7,8 +7,11 @@ var (
 	PostgreSQL = postgreSQL{}
 	// SQLite3 dialect
 	SQLite3 = sqlite3{}
+	// MSSQL dialect of SQL Server
+	MSSQL = msSQL{}
 )
 
 const (
-	timeFormat = "2006-01-02 15:04:05.000000"
+	timeFormat      = "2006-01-02 15:04:05.000000"
+	msSQLTimeFormat = "2006-01-02T15:04:05.000"
 )
@@ -3,6 +3,7 @@ package dialect
 import (
 	"testing"
 
+	"github.com/gokit/dbx/utils"
 	"github.com/stretchr/testify/require"
 )
 
@@ -59,3 +60,33 @@ func TestSQLite3(t *testing.T) {
 		require.Equal(t, test.want, SQLite3.QuoteIdent(test.in))
 	}
 }
+
+func TestMSSQL(t *testing.T) {
+	for _, test := range []struct {
+		in   string
+		want string
+	}{
+		{
+			in:   "table.col",
+			want: "[table].[col]",
+		},
+		{
+			in:   "col",
+			want: "[col]",
+		},
+	} {
+		require.Equal(t, test.want, MSSQL.QuoteIdent(test.in))
+	}
+	require.Equal(t, "@p1", MSSQL.Placeholder(0))
+	require.Equal(t, "N'it''s'", MSSQL.EncodeString("it's"))
+	require.Equal(t, "1", MSSQL.EncodeBool(true))
+	require.Equal(t, "0x0aff", MSSQL.EncodeBytes([]byte{0x0a, 0xff}))
+}
+
+func TestQuoteIdentEscape(t *testing.T) {
+	require.Equal(t, "`a``b`", MySQL.QuoteIdent("a`b"))
+	require.Equal(t, `"a""b"`, PostgreSQL.QuoteIdent(`a"b`))
+	require.Equal(t, `"t"."a""b"`, SQLite3.QuoteIdent(`t.a"b`))
+	require.Equal(t, `[t].[a]]b]`, MSSQL.QuoteIdent(`t.a]b`))
+	require.Equal(t, `[db].[table].[col]]umn]`, utils.QuoteIdentBrackets("db.table.col]umn"))
+}
@@ -0,0 +1,49 @@
+package dialect
+
+import (
+	"fmt"
+	"github.com/gokit/dbx/schema"
+	"github.com/gokit/dbx/utils"
+	"strings"
+	"time"
+)
+
+type msSQL struct{}
+
+func (d msSQL) DriverName() string {
+	return "mssql"
+}
+
+func (d msSQL) QuoteIdent(s string) string {
+	return utils.QuoteIdentBrackets(s)
+}
+
+func (d msSQL) EncodeString(s string) string {
+	// https://docs.microsoft.com/en-us/sql/t-sql/data-types/constants-transact-sql
+	return `N'` + strings.Replace(s, `'`, `''`, -1) + `'`
+}
+
+func (d msSQL) EncodeBool(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+func (d msSQL) EncodeTime(t time.Time) string {
+	// ISO 8601 is converted to datetime and datetime2 regardless of DATEFORMAT,
+	// but datetime takes no more than 3 fractional digits.
+	return `'` + t.UTC().Format(msSQLTimeFormat) + `'`
+}
+
+func (d msSQL) EncodeBytes(b []byte) string {
+	return fmt.Sprintf(`0x%x`, b)
+}
+
+func (d msSQL) Placeholder(n int) string {
+	return fmt.Sprintf("@p%d", n+1)
+}
+
+func (d msSQL) Schema(query schema.Query) schema.Dialect {
+	return schema.MSSQL(query)
+}
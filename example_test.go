@@ -1,12 +1,15 @@
 package dbx
 
 import (
+	"database/sql"
 	"fmt"
 	"time"
+
+	"github.com/gokit/dbx/dialect"
 )
 
 func ExampleOpen() {
-	// create a connection (e.g. "postgres", "mysql", or "sqlite3")
+	// create a connection (e.g. "postgres", "mysql", "sqlite3", or "sqlserver")
 	conn, _ := Open("postgres", "...", nil)
 	conn.SetMaxOpenConns(10)
 
@@ -17,6 +20,15 @@ func ExampleOpen() {
 	sess.Begin()
 }
 
+func ExampleNewConnection() {
+	// use a pool managed outside of dbx
+	db, _ := sql.Open("postgres", "...")
+	conn := NewConnection(db, dialect.PostgreSQL, nil)
+
+	sess := conn.NewSession(nil)
+	sess.Begin()
+}
+
 func ExampleSelect() {
 	Select("title", "body").
 		From("suggestions").
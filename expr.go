@@ -7,11 +7,29 @@ type raw struct {
 
 // Expr allows raw expression to be used when current SQL syntax is
 // not supported by gocraft/dbx.
+//
+// Instead of `?`, query can have named placeholders like `:name` or `@name`,
+// if the only value is a map with string keys or a struct.
+// A name can be used more than once.
 func Expr(query string, value ...interface{}) Builder {
 	return &raw{Query: query, Value: value}
 }
 
 func (raw *raw) Build(_ Dialect, buf Buffer) error {
+	if len(raw.Value) == 1 {
+		if arg, ok := namedArg(raw.Value[0]); ok {
+			if query, name, ok := parseNamed(raw.Query); ok {
+				value, err := bindNamed(arg, name)
+				if err != nil {
+					return err
+				}
+				buf.WriteString(query)
+				buf.WriteValue(value...)
+				return nil
+			}
+		}
+	}
+
 	buf.WriteString(raw.Query)
 	buf.WriteValue(raw.Value...)
 	return nil
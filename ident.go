@@ -1,5 +1,10 @@
 package dbx
 
+import (
+	"strings"
+	"unicode"
+)
+
 // I is quoted identifier
 type I string
 
@@ -23,3 +28,93 @@ func as(expr interface{}, alias string) Builder {
 		return nil
 	})
 }
+
+// identRef is a reference to a table or a column like `table.col AS alias`.
+type identRef struct {
+	name  string
+	alias string
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if !isIdentStart(r) && !(i > 0 && (r == '$' || unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseIdentRef parses s as `name`, `a.name`, `a.*` or `*`, optionally followed
+// by `AS alias`, or by `alias` if implicitAlias is true.
+// It returns false if s is anything else, like an expression.
+func parseIdentRef(s string, implicitAlias bool) (identRef, bool) {
+	var ref identRef
+	field := strings.Fields(s)
+	switch {
+	case len(field) == 1:
+	case len(field) == 3 && strings.EqualFold(field[1], "AS"):
+		ref.alias = field[2]
+	case len(field) == 2 && implicitAlias:
+		ref.alias = field[1]
+	default:
+		return ref, false
+	}
+	if ref.alias != "" && !isIdent(ref.alias) {
+		return ref, false
+	}
+
+	ref.name = field[0]
+	part := strings.Split(ref.name, ".")
+	for i, p := range part {
+		if p == "*" && i == len(part)-1 && ref.alias == "" {
+			continue
+		}
+		if !isIdent(p) {
+			return ref, false
+		}
+	}
+	return ref, true
+}
+
+func (ref identRef) Build(d Dialect, buf Buffer) error {
+	if strings.HasSuffix(ref.name, "*") {
+		if ref.name != "*" {
+			buf.WriteString(d.QuoteIdent(strings.TrimSuffix(ref.name, ".*")))
+			buf.WriteString(".")
+		}
+		buf.WriteString("*")
+	} else {
+		buf.WriteString(d.QuoteIdent(ref.name))
+	}
+	if ref.alias != "" {
+		buf.WriteString(" AS ")
+		buf.WriteString(d.QuoteIdent(ref.alias))
+	}
+	return nil
+}
+
+// buildIdentRef quotes s if it is a reference to a table or a column,
+// and writes it as it is otherwise.
+func buildIdentRef(d Dialect, buf Buffer, s string, implicitAlias bool) error {
+	if ref, ok := parseIdentRef(s, implicitAlias); ok {
+		return ref.Build(d, buf)
+	}
+	buf.WriteString(s)
+	return nil
+}
+
+// buildColumn quotes s as a column name, even if it is not a valid one.
+func buildColumn(d Dialect, buf Buffer, s string) {
+	if ref, ok := parseIdentRef(s, false); ok && ref.alias == "" {
+		ref.Build(d, buf)
+		return
+	}
+	buf.WriteString(d.QuoteIdent(s))
+}
@@ -15,13 +15,20 @@ type InsertStmt struct {
 
 	raw
 
-	Table        string
-	Column       []string
-	Value        [][]interface{}
-	Ignored      bool
-	ReturnColumn []string
-	RecordID     *int64
-	comments     Comments
+	Table            string
+	Column           []string
+	Value            [][]interface{}
+	Ignored          bool
+	ReturnColumn     []string
+	RecordID         *int64
+	keyColumn        []string
+	noReturnKey      bool
+	recordKeys       [][]interface{}
+	inTx             bool
+	maxAllowedPacket int
+	fromSelect       *SelectStmt
+	conflict         *onConflict
+	comments         Comments
 }
 
 type InsertBuilder = InsertStmt
@@ -35,7 +42,7 @@ func (b *InsertStmt) Build(d Dialect, buf Buffer) error {
 		return ErrTableNotSpecified
 	}
 
-	if len(b.Column) == 0 {
+	if len(b.Column) == 0 && b.fromSelect == nil {
 		return ErrColumnNotSpecified
 	}
 
@@ -44,39 +51,90 @@ func (b *InsertStmt) Build(d Dialect, buf Buffer) error {
 		return err
 	}
 
-	if b.Ignored {
+	conflict := b.conflict
+	switch {
+	case !b.Ignored:
+		buf.WriteString("INSERT INTO ")
+	case d.DriverName() == "mysql":
 		buf.WriteString("INSERT IGNORE INTO ")
-	} else {
+	case d.DriverName() == "sqlite":
+		buf.WriteString("INSERT OR IGNORE INTO ")
+	default:
 		buf.WriteString("INSERT INTO ")
+		if conflict == nil {
+			conflict = &onConflict{nothing: true}
+		}
 	}
 
 	buf.WriteString(d.QuoteIdent(b.Table))
 
-	var placeholderBuf strings.Builder
-	placeholderBuf.WriteString("(")
-	buf.WriteString(" (")
-	for i, col := range b.Column {
-		if i > 0 {
-			buf.WriteString(",")
-			placeholderBuf.WriteString(",")
+	// SQL Server has OUTPUT before the rows instead of RETURNING.
+	isMSSQL := d.DriverName() == "mssql"
+
+	if b.fromSelect != nil {
+		if len(b.Column) > 0 {
+			buf.WriteString(" (")
+			for i, col := range b.Column {
+				if i > 0 {
+					buf.WriteString(",")
+				}
+				buf.WriteString(d.QuoteIdent(col))
+			}
+			buf.WriteString(")")
 		}
-		buf.WriteString(d.QuoteIdent(col))
-		placeholderBuf.WriteString(placeholder)
-	}
-	buf.WriteString(") VALUES ")
-	placeholderBuf.WriteString(")")
-	placeholderStr := placeholderBuf.String()
+		if isMSSQL && len(b.ReturnColumn) > 0 {
+			buildOutput(d, buf, "INSERTED", b.ReturnColumn)
+		}
+		buf.WriteString(" ")
+		if conflict != nil && d.DriverName() == "sqlite" {
+			// sqlite3 cannot tell ON CONFLICT from a join constraint
+			// unless the select ends with a WHERE clause.
+			buf.WriteString("SELECT * FROM (")
+			buf.WriteString(placeholder)
+			buf.WriteString(") WHERE true")
+			buf.WriteValue(bare(b.fromSelect))
+		} else {
+			buf.WriteString(placeholder)
+			buf.WriteValue(bare(b.fromSelect))
+		}
+	} else {
+		var placeholderBuf strings.Builder
+		placeholderBuf.WriteString("(")
+		buf.WriteString(" (")
+		for i, col := range b.Column {
+			if i > 0 {
+				buf.WriteString(",")
+				placeholderBuf.WriteString(",")
+			}
+			buf.WriteString(d.QuoteIdent(col))
+			placeholderBuf.WriteString(placeholder)
+		}
+		buf.WriteString(")")
+		if isMSSQL && len(b.ReturnColumn) > 0 {
+			buildOutput(d, buf, "INSERTED", b.ReturnColumn)
+		}
+		buf.WriteString(" VALUES ")
+		placeholderBuf.WriteString(")")
+		placeholderStr := placeholderBuf.String()
 
-	for i, tuple := range b.Value {
-		if i > 0 {
-			buf.WriteString(", ")
+		for i, tuple := range b.Value {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(placeholderStr)
+
+			buf.WriteValue(tuple...)
 		}
-		buf.WriteString(placeholderStr)
+	}
 
-		buf.WriteValue(tuple...)
+	if conflict != nil {
+		err := conflict.build(d, buf, b.Column)
+		if err != nil {
+			return err
+		}
 	}
 
-	if len(b.ReturnColumn) > 0 {
+	if len(b.ReturnColumn) > 0 && !isMSSQL {
 		buf.WriteString(" RETURNING ")
 		for i, col := range b.ReturnColumn {
 			if i > 0 {
@@ -102,6 +160,7 @@ func (sess *Session) InsertInto(table string) *InsertStmt {
 	b.runner = sess
 	b.EventReceiver = sess.EventReceiver
 	b.Dialect = sess.Dialect
+	b.maxAllowedPacket = sess.maxAllowedPacket
 	return b
 }
 
@@ -111,6 +170,7 @@ func (tx *Tx) InsertInto(table string) *InsertStmt {
 	b.runner = tx
 	b.EventReceiver = tx.EventReceiver
 	b.Dialect = tx.Dialect
+	b.maxAllowedPacket = tx.maxAllowedPacket
 	return b
 }
 
@@ -153,12 +213,48 @@ func (b *InsertStmt) Comment(comment string) *InsertStmt {
 	return b
 }
 
-// Ignore any insertion errors
+// Ignore any insertion errors.
+// It is `INSERT IGNORE` in MySQL, `INSERT OR IGNORE` in SQLite3,
+// and `ON CONFLICT DO NOTHING` in PostgreSQL.
 func (b *InsertStmt) Ignore() *InsertStmt {
 	b.Ignored = true
 	return b
 }
 
+// OnConflict specifies the columns of the unique constraint that may conflict.
+// It must be followed by DoNothing or DoUpdateSet.
+//
+// MySQL does not support a conflict target, so column is ignored there
+// and the clause is built as `ON DUPLICATE KEY UPDATE`.
+func (b *InsertStmt) OnConflict(column ...string) *InsertStmt {
+	if b.conflict == nil {
+		b.conflict = &onConflict{}
+	}
+	b.conflict.column = column
+	return b
+}
+
+// DoNothing skips the rows that conflict.
+func (b *InsertStmt) DoNothing() *InsertStmt {
+	if b.conflict == nil {
+		b.conflict = &onConflict{}
+	}
+	b.conflict.nothing = true
+	b.conflict.set = nil
+	return b
+}
+
+// DoUpdateSet updates column with value in the rows that conflict.
+// Use Excluded to refer to the value proposed for insertion.
+func (b *InsertStmt) DoUpdateSet(column string, value interface{}) *InsertStmt {
+	if b.conflict == nil {
+		b.conflict = &onConflict{}
+	}
+	b.conflict.nothing = false
+	b.conflict.set = append(b.conflict.set, assignment{column: column, value: value})
+	return b
+}
+
 // Values adds a tuple to be inserted.
 // The order of the tuple should match Columns.
 func (b *InsertStmt) Values(value ...interface{}) *InsertStmt {
@@ -168,38 +264,71 @@ func (b *InsertStmt) Values(value ...interface{}) *InsertStmt {
 
 // Record adds a tuple for columns from a struct.
 //
-// If there is a field called "Id" or "ID" in the struct,
-// it will be set to LastInsertId.
+// The key of the struct is set after insertion. Key fields are tagged
+// with `pk` like `db:"id,pk"`, or the field for column id is the key.
+// In PostgreSQL, the key is scanned from `RETURNING`, so it can be
+// of any type and composite. The returned rows are matched with records
+// in the order of VALUES, which PostgreSQL follows but does not promise.
+// Otherwise, an integer key is set to LastInsertId.
+// Use NoReturnKey if the table has no column for the key, like id.
+//
+// In SQL Server, keys are not set, as the rows of `OUTPUT` come in no
+// particular order. Returning, which is built as `OUTPUT` there, fails
+// on tables with triggers.
 func (b *InsertStmt) Record(structValue interface{}) *InsertStmt {
 	v := reflect.Indirect(reflect.ValueOf(structValue))
 
 	if v.Kind() == reflect.Struct {
-		found := make([]interface{}, len(b.Column)+1)
-		// ID is recommended by golint here
-		s := newTagStore()
-		s.findValueByName(v, append(b.Column, "id"), found, false)
-
-		value := found[:len(found)-1]
-		for i, v := range value {
-			if v != nil {
-				value[i] = v.(reflect.Value).Interface()
-			}
-		}
+		b.addRecord(v)
+	}
+	return b
+}
 
-		if v.CanSet() {
-			switch idField := found[len(found)-1].(type) {
-			case reflect.Value:
-				if idField.Kind() == reflect.Int64 {
-					b.RecordID = idField.Addr().Interface().(*int64)
-				}
-			}
+// addRecord adds a tuple for columns from a struct,
+// and keeps the pointers to its key fields.
+func (b *InsertStmt) addRecord(v reflect.Value) {
+	s := newTagStore()
+	found := make([]interface{}, len(b.Column))
+	s.findValueByName(v, b.Column, found, false)
+	for i, v := range found {
+		if v != nil {
+			found[i] = v.(reflect.Value).Interface()
 		}
-		b.Values(value...)
 	}
+
+	column, key := s.keyFields(v)
+	if b.keyColumn == nil {
+		b.keyColumn = column
+	}
+	for len(b.recordKeys) < len(b.Value) {
+		b.recordKeys = append(b.recordKeys, nil)
+	}
+	if len(column) == len(b.keyColumn) {
+		b.recordKeys = append(b.recordKeys, key)
+	} else {
+		b.recordKeys = append(b.recordKeys, nil)
+	}
+	b.Values(found...)
+}
+
+// NoReturnKey does not set the keys of records after insertion,
+// so that `RETURNING` is not added in PostgreSQL.
+// It is needed if the struct has a field for column id, but the table does not.
+func (b *InsertStmt) NoReturnKey() *InsertStmt {
+	b.noReturnKey = true
+	return b
+}
+
+// FromSelect inserts the rows returned by a SelectStmt instead of Values.
+// Values, Record and Pair are ignored once it is set.
+// Columns is optional and should match the select columns.
+func (b *InsertStmt) FromSelect(s *SelectStmt) *InsertStmt {
+	b.fromSelect = s
 	return b
 }
 
 // Returning specifies the returning columns for postgres.
+// In SQL Server, it is `OUTPUT INSERTED`, which fails on tables with triggers.
 func (b *InsertStmt) Returning(column ...string) *InsertStmt {
 	b.ReturnColumn = column
 	return b
@@ -225,6 +354,16 @@ func (b *InsertStmt) Exec() (sql.Result, error) {
 }
 
 func (b *InsertStmt) ExecContext(ctx context.Context) (sql.Result, error) {
+	if b.raw.Query == "" && b.fromSelect == nil && (len(b.Value) > 1 || b.recordKeys != nil) {
+		chunk, err := b.chunk(b.Dialect)
+		if err != nil {
+			return nil, err
+		}
+		if len(chunk) > 1 || b.recordKeys != nil {
+			return b.execChunk(ctx, chunk)
+		}
+	}
+
 	result, err := exec(ctx, b.runner, b.EventReceiver, b, b.Dialect)
 	if err != nil {
 		return nil, err
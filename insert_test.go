@@ -1,8 +1,11 @@
 package dbx
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
 
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/gokit/dbx/dialect"
 	"github.com/stretchr/testify/require"
 )
@@ -58,3 +61,242 @@ func BenchmarkInsertRecordSQL(b *testing.B) {
 		}).Build(dialect.MySQL, buf)
 	}
 }
+
+func TestInsertIgnore(t *testing.T) {
+	for _, test := range []struct {
+		d    Dialect
+		want string
+	}{
+		{
+			d:    dialect.MySQL,
+			want: "INSERT IGNORE INTO `table` (`a`) VALUES (1)",
+		},
+		{
+			d:    dialect.PostgreSQL,
+			want: `INSERT INTO "table" ("a") VALUES (1) ON CONFLICT DO NOTHING`,
+		},
+		{
+			d:    dialect.SQLite3,
+			want: `INSERT OR IGNORE INTO "table" ("a") VALUES (1)`,
+		},
+	} {
+		s, err := ToRawSql(test.d, InsertInto("table").Ignore().Columns("a").Values(1))
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+}
+
+func TestInsertOnConflict(t *testing.T) {
+	for _, test := range []struct {
+		builder Builder
+		d       Dialect
+		want    string
+	}{
+		{
+			builder: InsertInto("table").Columns("a", "b").Values(1, "one").
+				OnConflict("a").DoUpdateSet("b", Excluded("b")),
+			d:    dialect.PostgreSQL,
+			want: `INSERT INTO "table" ("a","b") VALUES (1,'one') ON CONFLICT ("a") DO UPDATE SET "b" = EXCLUDED."b"`,
+		},
+		{
+			builder: InsertInto("table").Columns("a", "b").Values(1, "one").
+				OnConflict("a").DoUpdateSet("b", Excluded("b")).DoUpdateSet("c", 2),
+			d:    dialect.SQLite3,
+			want: `INSERT INTO "table" ("a","b") VALUES (1,'one') ON CONFLICT ("a") DO UPDATE SET "b" = EXCLUDED."b", "c" = 2`,
+		},
+		{
+			builder: InsertInto("table").Columns("a", "b").Values(1, "one").
+				OnConflict("a").DoUpdateSet("b", Excluded("b")),
+			d:    dialect.MySQL,
+			want: "INSERT INTO `table` (`a`,`b`) VALUES (1,'one') ON DUPLICATE KEY UPDATE `b` = VALUES(`b`)",
+		},
+		{
+			builder: InsertInto("table").Columns("a", "b").Values(1, "one").
+				OnConflict("a", "b").DoNothing().Returning("a"),
+			d:    dialect.PostgreSQL,
+			want: `INSERT INTO "table" ("a","b") VALUES (1,'one') ON CONFLICT ("a","b") DO NOTHING RETURNING "a"`,
+		},
+		{
+			builder: InsertInto("table").Columns("a", "b").Values(1, "one").
+				OnConflict().DoNothing(),
+			d:    dialect.MySQL,
+			want: "INSERT INTO `table` (`a`,`b`) VALUES (1,'one') ON DUPLICATE KEY UPDATE `a` = `a`",
+		},
+	} {
+		s, err := ToRawSql(test.d, test.builder)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+}
+
+func TestInsertFromSelect(t *testing.T) {
+	for _, test := range []struct {
+		builder Builder
+		d       Dialect
+		want    string
+	}{
+		{
+			builder: InsertInto("archive").Columns("a", "b").
+				FromSelect(Select("a", "b").From("table").Where(Gt("a", 1))),
+			d:    dialect.MySQL,
+			want: "INSERT INTO `archive` (`a`,`b`) SELECT `a`, `b` FROM `table` WHERE `a` > 1",
+		},
+		{
+			builder: InsertInto("archive").
+				FromSelect(Select("*").From("table")).
+				Returning("id"),
+			d:    dialect.PostgreSQL,
+			want: `INSERT INTO "archive" SELECT * FROM "table" RETURNING "id"`,
+		},
+		{
+			builder: InsertInto("archive").Columns("a", "b").
+				FromSelect(Select("a", "b").From("table")).
+				OnConflict("a").DoUpdateSet("b", Excluded("b")),
+			d:    dialect.PostgreSQL,
+			want: `INSERT INTO "archive" ("a","b") SELECT "a", "b" FROM "table" ON CONFLICT ("a") DO UPDATE SET "b" = EXCLUDED."b"`,
+		},
+		{
+			builder: InsertInto("archive").Columns("a", "b").
+				FromSelect(Select("a", "b").From("table")).
+				OnConflict("a").DoNothing(),
+			d:    dialect.SQLite3,
+			want: `INSERT INTO "archive" ("a","b") SELECT * FROM (SELECT "a", "b" FROM "table") WHERE true ON CONFLICT ("a") DO NOTHING`,
+		},
+	} {
+		s, err := ToRawSql(test.d, test.builder)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+}
+
+func TestInsertRecordsChunk(t *testing.T) {
+	people := make([]dbxPerson, 1000)
+	b := InsertInto("dbx_people").Columns("name", "email").Records(people)
+
+	chunk, err := b.chunk(dialect.SQLite3)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(chunk))
+	require.Equal(t, 499, len(chunk[0]))
+	require.Equal(t, 2, len(chunk[2]))
+
+	chunk, err = b.chunk(dialect.PostgreSQL)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(chunk))
+
+	chunk, err = b.MaxAllowedPacket(4096 + 100).chunk(dialect.MySQL)
+	require.NoError(t, err)
+	// each row is `('',''), `
+	require.Equal(t, 11, len(chunk[0]))
+	require.Equal(t, 91, len(chunk))
+
+	// the limit of the Connection is used by sessions and transactions
+	conn := &Connection{Dialect: dialect.MySQL, EventReceiver: nullReceiver}
+	sess := conn.SetMaxAllowedPacket(4096 + 100).NewSession(nil)
+	chunk, err = sess.InsertInto("dbx_people").Columns("name", "email").Records(people).chunk(dialect.MySQL)
+	require.NoError(t, err)
+	require.Equal(t, 91, len(chunk))
+}
+
+func TestSQLite3InsertRecords(t *testing.T) {
+	sess := sqlite3Session
+	reset(t, sess)
+
+	people := make([]*dbxPerson, 1000)
+	for i := range people {
+		people[i] = &dbxPerson{Name: fmt.Sprintf("p%d", i), Email: "a@b.c"}
+	}
+	result, err := sess.InsertInto("dbx_people").Columns("name", "email").Records(people).InTransaction().Exec()
+	require.NoError(t, err)
+	n, err := result.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), n)
+
+	var names []string
+	for _, i := range []int{0, 499, 500, 999} {
+		var name string
+		err := sess.Select("name").From("dbx_people").Where(Eq("id", people[i].Id)).LoadOne(&name)
+		require.NoError(t, err)
+		names = append(names, name)
+	}
+	require.Equal(t, []string{"p0", "p499", "p500", "p999"}, names)
+
+	// ids are set back into a slice of structs too
+	more := []dbxPerson{{Name: "x", Email: "x"}, {Name: "y", Email: "y"}}
+	_, err = sess.InsertInto("dbx_people").Columns("name", "email").Records(more).Exec()
+	require.NoError(t, err)
+	require.Equal(t, []int64{1001, 1002}, []int64{more[0].Id, more[1].Id})
+}
+
+func TestPostgresRecordKey(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	conn := &Connection{
+		DB:            db,
+		EventReceiver: &NullEventReceiver{},
+		Dialect:       dialect.PostgreSQL,
+	}
+	sess := conn.NewSession(nil)
+
+	// the field for column id is the key by default
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "dbx_people" ("name","email") VALUES ('a','a@test.com') RETURNING "id"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	person := dbxPerson{Name: "a", Email: "a@test.com"}
+	result, err := sess.InsertInto("dbx_people").Columns("name", "email").Record(&person).Exec()
+	require.NoError(t, err)
+	require.Equal(t, int64(7), person.Id)
+	n, err := result.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+
+	// a table without the id column opts out
+	type logEntry struct {
+		ID      int64
+		Message string
+	}
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "logs" ("message") VALUES ('a')`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	entry := logEntry{Message: "a"}
+	_, err = sess.InsertInto("logs").Columns("message").Record(&entry).NoReturnKey().Exec()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), entry.ID)
+
+	type token struct {
+		UUID  string `db:"uuid,pk"`
+		Owner uint64 `db:",pk"`
+		Value string
+	}
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "tokens" ("value") VALUES ('x'), ('y') RETURNING "uuid","owner"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"uuid", "owner"}).
+			AddRow("6ba7b810-9dad-11d1-80b4-00c04fd430c8", 1).
+			AddRow("6ba7b811-9dad-11d1-80b4-00c04fd430c8", 2))
+	tokens := []token{{Value: "x"}, {Value: "y"}}
+	_, err = sess.InsertInto("tokens").Columns("value").Records(tokens).Exec()
+	require.NoError(t, err)
+	require.Equal(t, []token{
+		{UUID: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", Owner: 1, Value: "x"},
+		{UUID: "6ba7b811-9dad-11d1-80b4-00c04fd430c8", Owner: 2, Value: "y"},
+	}, tokens)
+
+	// keys cannot be matched with records if rows might be skipped
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "tokens" ("value") VALUES ('z') ON CONFLICT DO NOTHING`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	_, err = sess.InsertInto("tokens").Columns("value").Record(&token{Value: "z"}).Ignore().Exec()
+	require.NoError(t, err)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLite3RecordKey(t *testing.T) {
+	sess := sqlite3Session
+	reset(t, sess)
+
+	type person struct {
+		Key  uint64 `db:"id,pk"`
+		Name string
+	}
+	var p person
+	_, err := sess.InsertInto("dbx_people").Columns("name").Record(&p).Exec()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), p.Key)
+}
@@ -3,6 +3,7 @@ package dbx
 import (
 	"database/sql/driver"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -12,6 +13,7 @@ type interpolator struct {
 	Buffer
 	Dialect
 	IgnoreBinary bool
+	BindAll      bool
 	N            int
 }
 
@@ -31,6 +33,9 @@ type interpolator struct {
 // The result of this is that it's way faster, and just as secure.
 //
 // Check out these benchmarks from https://github.com/tyler-smith/golang-sql-benchmark.
+//
+// Use Connection.UsePrepared or Session.UsePrepared to send values
+// as bind parameters of prepared statements instead.
 func InterpolateForDialect(query string, value []interface{}, d Dialect) (string, error) {
 	i := interpolator{
 		Buffer:  NewBuffer(),
@@ -65,12 +70,10 @@ func (i *interpolator) interpolate(query string, value []interface{}, topLevel b
 			return ErrPlaceholderCount
 		}
 
-		i.WriteString(query[:index])
-		if _, ok := value[valueIndex].([]byte); ok && i.IgnoreBinary {
-			i.WriteString(i.Placeholder(i.N))
-			i.N++
-			i.WriteValue(value[valueIndex])
+		if isEmptySlice(value[valueIndex]) {
+			i.writeEmptySet(query[:index])
 		} else {
+			i.WriteString(query[:index])
 			err := i.encodePlaceholder(value[valueIndex], topLevel)
 			if err != nil {
 				return err
@@ -90,11 +93,80 @@ func (i *interpolator) interpolate(query string, value []interface{}, topLevel b
 	return nil
 }
 
+var inOperator = regexp.MustCompile(`(?i)\b(NOT\s+)?IN\s*$`)
+
+// isEmptySlice tells whether value is an empty slice written as a set.
+// A driver.Valuer like pq.StringArray is encoded by itself instead.
+func isEmptySlice(value interface{}) bool {
+	if _, ok := value.(driver.Valuer); ok {
+		return false
+	}
+	v := reflect.ValueOf(value)
+	return v.Kind() == reflect.Slice && v.Len() == 0 && v.Type().Elem().Kind() != reflect.Uint8
+}
+
+// writeEmptySet writes query followed by an empty set in place of an empty slice.
+//
+// `IN ()` is only valid in SQLite3, so it is rewritten for other dialects
+// to match no rows, and `NOT IN ()` to match all rows like SQLite3 does.
+// An empty slice anywhere else is written as `(NULL)`.
+func (i *interpolator) writeEmptySet(query string) {
+	loc := inOperator.FindStringSubmatchIndex(query)
+	if loc == nil {
+		i.WriteString(query)
+		i.WriteString("(NULL)")
+		return
+	}
+
+	switch i.DriverName() {
+	case "sqlite":
+		i.WriteString(query)
+		i.WriteString("()")
+	case "postgres":
+		// an empty array literal takes the type of the column,
+		// while `SELECT NULL` would be text
+		i.WriteString(query[:loc[0]])
+		if loc[2] >= 0 {
+			i.WriteString("<> ALL ('{}')")
+		} else {
+			i.WriteString("= ANY ('{}')")
+		}
+	case "mysql":
+		i.WriteString(query)
+		i.WriteString("(SELECT NULL FROM DUAL WHERE FALSE)")
+	default:
+		i.WriteString(query)
+		i.WriteString("(SELECT NULL WHERE 1 = 0)")
+	}
+}
+
 var (
 	typeTime = reflect.TypeOf(time.Time{})
 )
 
+// bindable tells whether value should be sent as a bind parameter
+// instead of being interpolated.
+// With BindAll, a slice is still expanded to a list of bind parameters.
+func (i *interpolator) bindable(value interface{}) bool {
+	switch value.(type) {
+	case []byte:
+		return i.IgnoreBinary || i.BindAll
+	case Builder:
+		return false
+	case driver.Valuer:
+		return i.BindAll
+	}
+	return i.BindAll && reflect.ValueOf(value).Kind() != reflect.Slice
+}
+
 func (i *interpolator) encodePlaceholder(value interface{}, topLevel bool) error {
+	if i.bindable(value) {
+		i.WriteString(i.Placeholder(i.N))
+		i.N++
+		i.WriteValue(value)
+		return nil
+	}
+
 	if builder, ok := value.(Builder); ok {
 		pbuf := NewBuffer()
 		err := builder.Build(i.Dialect, pbuf)
@@ -103,7 +175,7 @@ func (i *interpolator) encodePlaceholder(value interface{}, topLevel bool) error
 		}
 		paren := false
 		switch value.(type) {
-		case *SelectStmt, *union:
+		case *SelectStmt, *UnionStmt:
 			paren = !topLevel
 		}
 		if paren {
@@ -161,8 +233,8 @@ func (i *interpolator) encodePlaceholder(value interface{}, topLevel bool) error
 			return nil
 		}
 		if v.Len() == 0 {
-			// FIXME: support zero-length slice
-			return ErrInvalidSliceLength
+			i.WriteString("(NULL)")
+			return nil
 		}
 		i.WriteString("(")
 		for n := 0; n < v.Len(); n++ {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gokit/dbx/dialect"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 )
 
@@ -104,7 +105,7 @@ func TestInterpolateForDialect(t *testing.T) {
 		{
 			query: "?",
 			value: []interface{}{Select("a").From("table")},
-			want:  "SELECT a FROM table",
+			want:  "SELECT `a` FROM `table`",
 		},
 		{
 			query: "?",
@@ -114,7 +115,7 @@ func TestInterpolateForDialect(t *testing.T) {
 		{
 			query: "?",
 			value: []interface{}{Select("a").From("table").As("a1")},
-			want:  "(SELECT a FROM table) AS `a1`",
+			want:  "(SELECT `a` FROM `table`) AS `a1`",
 		},
 		{
 			query: "?",
@@ -126,7 +127,7 @@ func TestInterpolateForDialect(t *testing.T) {
 			},
 			// parentheses around union subqueries are not supported in sqlite
 			// but supported in both mysql and postgres.
-			want: "(SELECT a FROM table1 UNION ALL SELECT b FROM table2) AS `t`",
+			want: "(SELECT `a` FROM `table1` UNION ALL SELECT `b` FROM `table2`) AS `t`",
 		},
 		{
 			query: "?",
@@ -177,6 +178,88 @@ func TestCommonSQLInjections(t *testing.T) {
 	}
 }
 
+func TestInterpolateEmptySlice(t *testing.T) {
+	for _, test := range []struct {
+		d     Dialect
+		query string
+		value []interface{}
+		want  string
+	}{
+		{
+			d:     dialect.MySQL,
+			query: "id IN ? AND name NOT IN ?",
+			value: []interface{}{[]int{}, []string{}},
+			want:  "id IN (SELECT NULL FROM DUAL WHERE FALSE) AND name NOT IN (SELECT NULL FROM DUAL WHERE FALSE)",
+		},
+		{
+			d:     dialect.PostgreSQL,
+			query: "id in ? AND name NOT  IN ?",
+			value: []interface{}{[]int{}, []string{}},
+			want:  "id = ANY ('{}') AND name <> ALL ('{}')",
+		},
+		{
+			d:     dialect.SQLite3,
+			query: "id IN ? AND name NOT IN ?",
+			value: []interface{}{[]int{}, []interface{}{}},
+			want:  "id IN () AND name NOT IN ()",
+		},
+		{
+			d:     dialect.MySQL,
+			query: "login = ? OR tags = ?",
+			value: []interface{}{"in", []string{}},
+			want:  "login = 'in' OR tags = (NULL)",
+		},
+		{
+			d:     dialect.PostgreSQL,
+			query: "id IN ?",
+			value: []interface{}{[]int{1, 2}},
+			want:  "id IN (1,2)",
+		},
+		{
+			// an empty array column is not NULL
+			d:     dialect.PostgreSQL,
+			query: "tags = ?",
+			value: []interface{}{pq.StringArray{}},
+			want:  "tags = '{}'",
+		},
+	} {
+		s, err := InterpolateForDialect(test.query, test.value, test.d)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+}
+
+func TestBindEmptyValuer(t *testing.T) {
+	i := interpolator{
+		Buffer:  NewBuffer(),
+		Dialect: dialect.PostgreSQL,
+		BindAll: true,
+	}
+	err := i.interpolate("tags = ?", []interface{}{pq.StringArray{}}, true)
+	require.NoError(t, err)
+	require.Equal(t, "tags = $1", i.String())
+	require.Equal(t, []interface{}{pq.StringArray{}}, i.Value())
+}
+
+func TestSQLite3EmptySlice(t *testing.T) {
+	sess := sqlite3Session
+	reset(t, sess)
+
+	for _, name := range []string{"a", "b"} {
+		_, err := sess.InsertInto("dbx_people").Pair("name", name).Exec()
+		require.NoError(t, err)
+	}
+
+	var n int
+	err := sess.Select("count(*)").From("dbx_people").Where("name IN ?", []string{}).LoadOne(&n)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+
+	err = sess.Select("count(*)").From("dbx_people").Where("name NOT IN ?", []string{}).LoadOne(&n)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+}
+
 // InjectionAttempts is a newline separated list of common SQL injection exploits
 // taken from https://wfuzz.googlecode.com/svn/trunk/wordlist/Injections/SQL.txt
 
@@ -21,13 +21,7 @@ func join(t joinType, table interface{}, on interface{}) Builder {
 			buf.WriteString("FULL ")
 		}
 		buf.WriteString("JOIN ")
-		switch table := table.(type) {
-		case string:
-			buf.WriteString(d.QuoteIdent(table))
-		default:
-			buf.WriteString(placeholder)
-			buf.WriteValue(table)
-		}
+		buildTable(d, buf, table)
 		buf.WriteString(" ON ")
 		switch on := on.(type) {
 		case string:
@@ -39,3 +33,77 @@ func join(t joinType, table interface{}, on interface{}) Builder {
 		return nil
 	})
 }
+
+// buildTable writes a table name with an optional alias,
+// or a Builder like SelectStmt as a subquery.
+func buildTable(d Dialect, buf Buffer, table interface{}) {
+	switch table := table.(type) {
+	case string:
+		if ref, ok := parseIdentRef(table, true); ok {
+			ref.Build(d, buf)
+		} else {
+			buf.WriteString(d.QuoteIdent(table))
+		}
+	default:
+		buf.WriteString(placeholder)
+		buf.WriteValue(table)
+	}
+}
+
+// joinClause keeps the parts of a join for statements like UPDATE and DELETE,
+// where some dialects have to move the first joined table to FROM or USING.
+type joinClause struct {
+	t     joinType
+	table interface{}
+	on    interface{}
+}
+
+func (j joinClause) Build(d Dialect, buf Buffer) error {
+	return join(j.t, j.table, j.on).Build(d, buf)
+}
+
+// cond returns the join condition to be used in WHERE.
+func (j joinClause) cond() Builder {
+	switch on := j.on.(type) {
+	case string:
+		return Expr(on)
+	case Builder:
+		return on
+	}
+	return nil
+}
+
+// buildJoinedTables writes `table[ JOIN ...]` for dialects that list the other
+// tables of UPDATE and DELETE in FROM or USING.
+// If table is nil, the first join becomes the table, and its condition is returned
+// to be added to WHERE. Only inner joins can be moved that way.
+func buildJoinedTables(d Dialect, buf Buffer, table interface{}, joins []joinClause) (Builder, error) {
+	var cond Builder
+	if table == nil {
+		if joins[0].t != inner {
+			return nil, ErrNotSupported
+		}
+		table = joins[0].table
+		cond = joins[0].cond()
+		joins = joins[1:]
+	}
+	buildTable(d, buf, table)
+	for _, j := range joins {
+		err := j.Build(d, buf)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cond, nil
+}
+
+// mergeCond prepends a join condition to WHERE conditions.
+func mergeCond(on Builder, cond []Builder) []Builder {
+	if on == nil {
+		return cond
+	}
+	if len(cond) == 0 {
+		return []Builder{on}
+	}
+	return []Builder{on, logicCond(cond...)}
+}
@@ -0,0 +1,218 @@
+package dbx
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// jsonPathElem is a key, or an array index if key is empty.
+type jsonPathElem struct {
+	key   string
+	index uint64
+}
+
+// parseJSONPath parses a path like `$.a.b[0]`. The leading `$.` can be omitted.
+func parseJSONPath(path string) ([]jsonPathElem, error) {
+	var elem []jsonPathElem
+	s := path
+	if strings.HasPrefix(s, "$") {
+		s = s[1:]
+	} else if s != "" && s[0] != '[' {
+		s = "." + s
+	}
+	for s != "" {
+		switch s[0] {
+		case '.':
+			end := strings.IndexAny(s[1:], ".[") + 1
+			if end == 0 {
+				end = len(s)
+			}
+			if end == 1 {
+				return nil, ErrInvalidJSONPath
+			}
+			elem = append(elem, jsonPathElem{key: s[1:end]})
+			s = s[end:]
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end == -1 {
+				return nil, ErrInvalidJSONPath
+			}
+			index, err := strconv.ParseUint(s[1:end], 10, 64)
+			if err != nil {
+				return nil, ErrInvalidJSONPath
+			}
+			elem = append(elem, jsonPathElem{index: index})
+			s = s[end+1:]
+		default:
+			return nil, ErrInvalidJSONPath
+		}
+	}
+	return elem, nil
+}
+
+// formatJSONPath formats path for MySQL and SQLite3, quoting keys if needed.
+func formatJSONPath(path []jsonPathElem) string {
+	var b strings.Builder
+	b.WriteString("$")
+	for _, e := range path {
+		switch {
+		case e.key == "":
+			b.WriteString("[")
+			b.WriteString(strconv.FormatUint(e.index, 10))
+			b.WriteString("]")
+		case isIdent(e.key):
+			b.WriteString(".")
+			b.WriteString(e.key)
+		default:
+			b.WriteString(`."`)
+			b.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(e.key))
+			b.WriteString(`"`)
+		}
+	}
+	return b.String()
+}
+
+// JSONExtractExpr builds an expression that extracts a value from a JSON column.
+type JSONExtractExpr struct {
+	column string
+	path   []jsonPathElem
+	text   bool
+	err    error
+}
+
+// JSONExtract extracts the JSON value at path like `$.a.b[0]` from column.
+// It is `->` in PostgreSQL, `JSON_EXTRACT` in MySQL and `json_extract` in SQLite3.
+func JSONExtract(column, path string) *JSONExtractExpr {
+	p, err := parseJSONPath(path)
+	return &JSONExtractExpr{
+		column: column,
+		path:   p,
+		err:    err,
+	}
+}
+
+// JSONExtractText is like JSONExtract, but a JSON string is unquoted.
+// It is `->>` in PostgreSQL and `JSON_UNQUOTE(JSON_EXTRACT(...))` in MySQL.
+func JSONExtractText(column, path string) *JSONExtractExpr {
+	e := JSONExtract(column, path)
+	e.text = true
+	return e
+}
+
+func (e *JSONExtractExpr) Build(d Dialect, buf Buffer) error {
+	if e.err != nil {
+		return e.err
+	}
+
+	switch d.DriverName() {
+	case "postgres":
+		buildColumn(d, buf, e.column)
+		for i, p := range e.path {
+			if e.text && i == len(e.path)-1 {
+				buf.WriteString(" ->> ")
+			} else {
+				buf.WriteString(" -> ")
+			}
+			buf.WriteString(placeholder)
+			if p.key == "" {
+				buf.WriteValue(p.index)
+			} else {
+				buf.WriteValue(p.key)
+			}
+		}
+	case "mysql":
+		if e.text {
+			buf.WriteString("JSON_UNQUOTE(")
+		}
+		buf.WriteString("JSON_EXTRACT(")
+		buildColumn(d, buf, e.column)
+		buf.WriteString(", ")
+		buf.WriteString(placeholder)
+		buf.WriteValue(formatJSONPath(e.path))
+		buf.WriteString(")")
+		if e.text {
+			buf.WriteString(")")
+		}
+	case "sqlite":
+		// json_extract returns SQL text for a JSON string anyway
+		buf.WriteString("json_extract(")
+		buildColumn(d, buf, e.column)
+		buf.WriteString(", ")
+		buf.WriteString(placeholder)
+		buf.WriteValue(formatJSONPath(e.path))
+		buf.WriteString(")")
+	default:
+		return ErrNotSupported
+	}
+	return nil
+}
+
+// As creates alias for the extracted value.
+func (e *JSONExtractExpr) As(alias string) Builder {
+	return as(e, alias)
+}
+
+// JSONContains checks whether the JSON column contains value,
+// which is encoded to JSON.
+// It is `@>` in PostgreSQL and `JSON_CONTAINS` in MySQL.
+// It is not supported in SQLite3.
+func JSONContains(column string, value interface{}) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		doc, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		switch d.DriverName() {
+		case "postgres":
+			buildColumn(d, buf, column)
+			buf.WriteString(" @> ")
+			buf.WriteString(placeholder)
+		case "mysql":
+			buf.WriteString("JSON_CONTAINS(")
+			buildColumn(d, buf, column)
+			buf.WriteString(", ")
+			buf.WriteString(placeholder)
+			buf.WriteString(")")
+		default:
+			return ErrNotSupported
+		}
+		buf.WriteValue(string(doc))
+		return nil
+	})
+}
+
+// JSONHasKey checks whether the JSON object in column has a top-level key.
+// It is `?` in PostgreSQL, `JSON_CONTAINS_PATH` in MySQL and `json_type` in SQLite3.
+func JSONHasKey(column, key string) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		path := formatJSONPath([]jsonPathElem{{key: key}})
+
+		switch d.DriverName() {
+		case "postgres":
+			buildColumn(d, buf, column)
+			// the operator must be escaped, or it is taken as a placeholder
+			buf.WriteString(" ?? ")
+			buf.WriteString(placeholder)
+			buf.WriteValue(key)
+		case "mysql":
+			buf.WriteString("JSON_CONTAINS_PATH(")
+			buildColumn(d, buf, column)
+			buf.WriteString(", 'one', ")
+			buf.WriteString(placeholder)
+			buf.WriteString(")")
+			buf.WriteValue(path)
+		case "sqlite":
+			buf.WriteString("json_type(")
+			buildColumn(d, buf, column)
+			buf.WriteString(", ")
+			buf.WriteString(placeholder)
+			buf.WriteString(") IS NOT NULL")
+			buf.WriteValue(path)
+		default:
+			return ErrNotSupported
+		}
+		return nil
+	})
+}
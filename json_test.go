@@ -0,0 +1,72 @@
+package dbx
+
+import (
+	"testing"
+
+	"github.com/gokit/dbx/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSON(t *testing.T) {
+	for _, test := range []struct {
+		builder Builder
+		d       Dialect
+		want    string
+	}{
+		{
+			builder: JSONExtract("data", "$.user.tags[0]"),
+			d:       dialect.PostgreSQL,
+			want:    `"data" -> 'user' -> 'tags' -> 0`,
+		},
+		{
+			builder: JSONExtractText("data", "user.name").As("name"),
+			d:       dialect.PostgreSQL,
+			want:    `"data" -> 'user' ->> 'name' AS "name"`,
+		},
+		{
+			builder: JSONExtractText("data", "$.user.name"),
+			d:       dialect.MySQL,
+			want:    "JSON_UNQUOTE(JSON_EXTRACT(`data`, '$.user.name'))",
+		},
+		{
+			builder: JSONExtract("data", "$.first name[1]"),
+			d:       dialect.SQLite3,
+			want:    `json_extract("data", '$."first name"[1]')`,
+		},
+		{
+			builder: JSONContains("data", map[string]interface{}{"role": "admin"}),
+			d:       dialect.PostgreSQL,
+			want:    `"data" @> '{"role":"admin"}'`,
+		},
+		{
+			builder: JSONContains("tags", "it's"),
+			d:       dialect.MySQL,
+			want:    "JSON_CONTAINS(`tags`, '\\\"it\\'s\\\"')",
+		},
+		{
+			builder: And(JSONHasKey("data", "role"), Eq("id", 1)),
+			d:       dialect.PostgreSQL,
+			want:    `("data" ? 'role') AND ("id" = 1)`,
+		},
+		{
+			builder: JSONHasKey("data", "role"),
+			d:       dialect.MySQL,
+			want:    "JSON_CONTAINS_PATH(`data`, 'one', '$.role')",
+		},
+		{
+			builder: JSONHasKey("data", `a"b`),
+			d:       dialect.SQLite3,
+			want:    `json_type("data", '$."a\"b"') IS NOT NULL`,
+		},
+	} {
+		s, err := ToRawSql(test.d, test.builder)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+
+	_, err := ToRawSql(dialect.MySQL, JSONExtract("data", "$.a[x]"))
+	require.Equal(t, ErrInvalidJSONPath, err)
+
+	_, err = ToRawSql(dialect.SQLite3, JSONContains("data", 1))
+	require.Equal(t, ErrNotSupported, err)
+}
@@ -0,0 +1,118 @@
+package dbx
+
+type lockStrength uint8
+
+const (
+	lockUpdate lockStrength = iota
+	lockShare
+)
+
+type lockWait uint8
+
+const (
+	lockWaitDefault lockWait = iota
+	lockNoWait
+	lockSkipLocked
+)
+
+// rowLock builds `FOR UPDATE` and `FOR SHARE` of a SelectStmt.
+type rowLock struct {
+	strength lockStrength
+	wait     lockWait
+	of       []string
+}
+
+// build writes the locking clause with a leading space.
+// With strict, it fails in dialects without row locks.
+func (l *rowLock) build(d Dialect, buf Buffer, strict bool) error {
+	switch d.DriverName() {
+	case "mssql":
+		// SQL Server locks by table hints instead
+		return ErrNotSupported
+	case "sqlite":
+		if strict {
+			return ErrNotSupported
+		}
+		return nil
+	case "mysql":
+		// LOCK IN SHARE MODE also works before MySQL 8,
+		// but takes no options.
+		if l.strength == lockShare && len(l.of) == 0 && l.wait == lockWaitDefault {
+			buf.WriteString(" LOCK IN SHARE MODE")
+			return nil
+		}
+	}
+
+	switch l.strength {
+	case lockShare:
+		buf.WriteString(" FOR SHARE")
+	default:
+		buf.WriteString(" FOR UPDATE")
+	}
+	if len(l.of) > 0 {
+		buf.WriteString(" OF ")
+		for i, table := range l.of {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(d.QuoteIdent(table))
+		}
+	}
+	switch l.wait {
+	case lockNoWait:
+		buf.WriteString(" NOWAIT")
+	case lockSkipLocked:
+		buf.WriteString(" SKIP LOCKED")
+	}
+	return nil
+}
+
+func (b *SelectStmt) rowLock() *rowLock {
+	if b.lock == nil {
+		b.lock = &rowLock{}
+	}
+	return b.lock
+}
+
+// StrictLock makes building the locking clause fail with ErrNotSupported
+// in dialects without row locks like SQLite3.
+// By default the clause is omitted there, as SQLite3 locks the whole
+// database in a write transaction anyway.
+func (b *SelectStmt) StrictLock() *SelectStmt {
+	b.strictLock = true
+	return b
+}
+
+// ForUpdate locks the selected rows for update.
+func (b *SelectStmt) ForUpdate() *SelectStmt {
+	b.rowLock().strength = lockUpdate
+	return b
+}
+
+// ForShare locks the selected rows against update by others.
+// It is `LOCK IN SHARE MODE` in MySQL unless Of, NoWait or SkipLocked is used.
+func (b *SelectStmt) ForShare() *SelectStmt {
+	b.rowLock().strength = lockShare
+	return b
+}
+
+// SkipLocked skips the rows that cannot be locked immediately.
+// It implies ForUpdate if no lock is specified.
+func (b *SelectStmt) SkipLocked() *SelectStmt {
+	b.rowLock().wait = lockSkipLocked
+	return b
+}
+
+// NoWait fails instead of waiting for the rows to be unlocked.
+// It implies ForUpdate if no lock is specified.
+func (b *SelectStmt) NoWait() *SelectStmt {
+	b.rowLock().wait = lockNoWait
+	return b
+}
+
+// Of limits the lock to the rows of tables.
+// It implies ForUpdate if no lock is specified.
+func (b *SelectStmt) Of(table ...string) *SelectStmt {
+	b.rowLock().of = table
+	return b
+}
@@ -0,0 +1,59 @@
+package dbx
+
+import (
+	"testing"
+
+	"github.com/gokit/dbx/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowLock(t *testing.T) {
+	for _, test := range []struct {
+		builder Builder
+		d       Dialect
+		want    string
+	}{
+		{
+			builder: Select("*").From("t").ForUpdate(),
+			d:       dialect.PostgreSQL,
+			want:    `SELECT * FROM "t" FOR UPDATE`,
+		},
+		{
+			builder: Select("*").From("t").Join("u", "t.u_id = u.id").ForShare().Of("t").SkipLocked(),
+			d:       dialect.PostgreSQL,
+			want:    `SELECT * FROM "t" JOIN "u" ON t.u_id = u.id FOR SHARE OF "t" SKIP LOCKED`,
+		},
+		{
+			builder: Select("*").From("t").Limit(1).NoWait(),
+			d:       dialect.PostgreSQL,
+			want:    `SELECT * FROM "t" LIMIT 1 FOR UPDATE NOWAIT`,
+		},
+		{
+			builder: Select("*").From("t").ForShare(),
+			d:       dialect.MySQL,
+			want:    "SELECT * FROM `t` LOCK IN SHARE MODE",
+		},
+		{
+			builder: Select("*").From("t").ForShare().SkipLocked(),
+			d:       dialect.MySQL,
+			want:    "SELECT * FROM `t` FOR SHARE SKIP LOCKED",
+		},
+		{
+			builder: Select("*").From("t").ForUpdate().Of("t").NoWait(),
+			d:       dialect.MySQL,
+			want:    "SELECT * FROM `t` FOR UPDATE OF `t` NOWAIT",
+		},
+		{
+			builder: Select("*").From("t").ForUpdate(),
+			d:       dialect.SQLite3,
+			want:    `SELECT * FROM "t"`,
+		},
+	} {
+		s, err := ToRawSql(test.d, test.builder)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+
+	_, err := ToRawSql(dialect.SQLite3, Select("*").From("t").ForUpdate().StrictLock())
+	require.Equal(t, ErrNotSupported, err)
+}
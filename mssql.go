@@ -0,0 +1,48 @@
+package dbx
+
+import "strconv"
+
+// buildTop writes `TOP (n)` of SQL Server with a trailing space.
+func buildTop(buf Buffer, n int64) {
+	buf.WriteString("TOP (")
+	buf.WriteString(strconv.FormatInt(n, 10))
+	buf.WriteString(") ")
+}
+
+// buildOffsetFetch writes `OFFSET ... FETCH` of SQL Server instead of LIMIT and OFFSET.
+// It must follow ORDER BY, so rows are ordered by nothing in particular
+// if the statement is not ordered.
+func buildOffsetFetch(buf Buffer, limit, offset int64, ordered bool) {
+	if !ordered {
+		buf.WriteString(" ORDER BY (SELECT NULL)")
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	buf.WriteString(" OFFSET ")
+	buf.WriteString(strconv.FormatInt(offset, 10))
+	buf.WriteString(" ROWS")
+	if limit >= 0 {
+		buf.WriteString(" FETCH NEXT ")
+		buf.WriteString(strconv.FormatInt(limit, 10))
+		buf.WriteString(" ROWS ONLY")
+	}
+}
+
+// buildOutput writes `OUTPUT INSERTED.column` of SQL Server instead of RETURNING.
+// prefix is INSERTED or DELETED, and column can be `*`.
+func buildOutput(d Dialect, buf Buffer, prefix string, column []string) {
+	buf.WriteString(" OUTPUT ")
+	for i, col := range column {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(prefix)
+		buf.WriteString(".")
+		if col == "*" {
+			buf.WriteString(col)
+		} else {
+			buf.WriteString(d.QuoteIdent(col))
+		}
+	}
+}
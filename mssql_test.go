@@ -0,0 +1,199 @@
+package dbx
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/gokit/dbx/dialect"
+	"github.com/gokit/dbx/schema"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMSSQLBuild(t *testing.T) {
+	for _, test := range []struct {
+		builder Builder
+		query   string
+	}{
+		{
+			builder: Select("id", "name").From("dbx_people").Where(Eq("name", "a")).OrderAsc("id").Limit(10),
+			query:   "SELECT TOP (10) [id], [name] FROM [dbx_people] WHERE [name] = N'a' ORDER BY [id] ASC",
+		},
+		{
+			builder: Select("id").Distinct().From("dbx_people").Limit(5),
+			query:   "SELECT DISTINCT TOP (5) [id] FROM [dbx_people]",
+		},
+		{
+			builder: Select("id").From("dbx_people").OrderDesc("id").Limit(10).Offset(20),
+			query:   "SELECT [id] FROM [dbx_people] ORDER BY [id] DESC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+		},
+		{
+			// OFFSET requires ORDER BY
+			builder: Select("id").From("dbx_people").Offset(20),
+			query:   "SELECT [id] FROM [dbx_people] ORDER BY (SELECT NULL) OFFSET 20 ROWS",
+		},
+		{
+			builder: Union(Select("id").From("a"), Select("id").From("b")).OrderAsc("id").Limit(3),
+			query:   "SELECT [id] FROM [a] UNION SELECT [id] FROM [b] ORDER BY [id] ASC OFFSET 0 ROWS FETCH NEXT 3 ROWS ONLY",
+		},
+		{
+			builder: Update("dbx_people").Set("name", "b").Where(Eq("id", 1)).Limit(1).Returning("id", "name"),
+			query:   "UPDATE TOP (1) [dbx_people] SET [name] = N'b' OUTPUT INSERTED.[id],INSERTED.[name] WHERE [id] = 1",
+		},
+		{
+			builder: DeleteFrom("dbx_people").Where(Eq("id", 1)).Limit(1).Returning("id"),
+			query:   "DELETE TOP (1) FROM [dbx_people] OUTPUT DELETED.[id] WHERE [id] = 1",
+		},
+		{
+			builder: InsertInto("dbx_people").Columns("name", "email").Values("a", "a@test.com").Returning("id"),
+			query:   "INSERT INTO [dbx_people] ([name],[email]) OUTPUT INSERTED.[id] VALUES (N'a',N'a@test.com')",
+		},
+		{
+			builder: InsertInto("dbx_people").Columns("name").FromSelect(Select("name").From("t")).Returning("*"),
+			query:   "INSERT INTO [dbx_people] ([name]) OUTPUT INSERTED.* SELECT [name] FROM [t]",
+		},
+		{
+			builder: Select("*").From("dbx_people").Where(Eq("active", true)),
+			query:   "SELECT * FROM [dbx_people] WHERE [active] = 1",
+		},
+	} {
+		query, err := ToRawSql(dialect.MSSQL, test.builder)
+		require.NoError(t, err)
+		require.Equal(t, test.query, query)
+	}
+
+	_, err := ToRawSql(dialect.MSSQL, InsertInto("t").Columns("a").Values(1).Ignore())
+	require.Equal(t, ErrNotSupported, err)
+	_, err = ToRawSql(dialect.MSSQL, Select("a").From("t").ForUpdate())
+	require.Equal(t, ErrNotSupported, err)
+}
+
+func TestMSSQLSQLMock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	conn := &Connection{
+		DB:            db,
+		EventReceiver: &NullEventReceiver{},
+		Dialect:       dialect.MSSQL,
+	}
+	sess := conn.NewSession(nil)
+
+	updateSQL := regexp.QuoteMeta(`UPDATE [dbx_people] SET [name] = @p1 WHERE [id] = @p2`)
+	mock.ExpectPrepare(updateSQL)
+	mock.ExpectExec(updateSQL).
+		WithArgs("b", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err = conn.NewSession(nil).UsePrepared(true).Update("dbx_people").Set("name", "b").Where(Eq("id", 1)).Exec()
+	require.NoError(t, err)
+
+	// keys are not read from OUTPUT INSERTED, as its rows are unordered
+	type person struct {
+		ID    int64 `db:"id,pk"`
+		Name  string
+		Email string
+	}
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO [dbx_people] ([name],[email]) VALUES (N'a',N'a@test.com'), (N'b',N'b@test.com')`)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	people := []person{{Name: "a", Email: "a@test.com"}, {Name: "b", Email: "b@test.com"}}
+	_, err = sess.InsertInto("dbx_people").Columns("name", "email").Records(people).Exec()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), people[0].ID)
+
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE [dbx_people](\n" +
+		"\t[id] int NOT NULL IDENTITY(1,1),\n" +
+		"\t[name] nvarchar(100) NOT NULL,\n" +
+		"\t[email] nvarchar(255) NULL,\n" +
+		"\tPRIMARY KEY ([id])\n" +
+		");\n" +
+		"EXEC sp_addextendedproperty N'MS_Description', N'mail', N'SCHEMA', N'dbo', N'TABLE', N'dbx_people', N'COLUMN', N'email';")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	err = sess.CreateTable("dbx_people", func(table *schema.TableSchema) {
+		table.ID("id")
+		table.String("name", schema.Length(100))
+		table.String("email", schema.Length(255), schema.Nullable(true), schema.Comment("mail"))
+	})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`select count(*) from information_schema.tables where table_name = N'dbx_people' and table_type = 'BASE TABLE'`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	exists, err := sess.TableExists("dbx_people")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE c.TABLE_NAME = @p1 AND c.TABLE_SCHEMA = @p2 ORDER BY c.ORDINAL_POSITION ASC`)).
+		WithArgs("dbx_people", "dbo").
+		WillReturnRows(sqlmock.NewRows([]string{"column_name", "data_type", "size", "is_nullable", "is_pkey", "is_autoinc"}).
+			AddRow("id", "int", nil, false, true, true).
+			AddRow("name", "nvarchar", 100, false, false, false))
+	columns, err := sess.GetColumns("dbx_people", "dbo")
+	require.NoError(t, err)
+	require.Len(t, columns, 2)
+	require.True(t, columns[0].AutoIncrement())
+	require.Equal(t, schema.TypeString, columns[1].DataType())
+	require.Equal(t, 100, columns[1].Length())
+
+	mock.ExpectExec(regexp.QuoteMeta(`DROP TABLE [dbx_people];`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	_, err = sess.Drop("dbx_people")
+	require.NoError(t, err)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMSSQLInsertRecordsChunk(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	conn := &Connection{
+		DB:            db,
+		EventReceiver: &NullEventReceiver{},
+		Dialect:       dialect.MSSQL,
+	}
+	sess := conn.NewSession(nil)
+
+	type tag struct {
+		Name string
+	}
+	tags := make([]tag, 1500)
+	for i := range tags {
+		tags[i] = tag{Name: fmt.Sprintf("t%d", i)}
+	}
+
+	// VALUES takes no more than 1000 rows
+	mock.ExpectExec(`^INSERT INTO \[tags\] \(\[name\]\) VALUES (\(N't\d+'\)(, )?){1000}$`).
+		WillReturnResult(sqlmock.NewResult(0, 1000))
+	mock.ExpectExec(`^INSERT INTO \[tags\] \(\[name\]\) VALUES (\(N't\d+'\)(, )?){500}$`).
+		WillReturnResult(sqlmock.NewResult(0, 500))
+	result, err := sess.InsertInto("tags").Columns("name").Records(tags).Exec()
+	require.NoError(t, err)
+	n, err := result.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(1500), n)
+
+	// and so does CopyFrom
+	mock.ExpectBegin()
+	mock.ExpectExec(`^INSERT INTO \[tags\] \(\[name\]\) VALUES (\(N't\d+'\)(, )?){1000}$`).
+		WillReturnResult(sqlmock.NewResult(0, 1000))
+	mock.ExpectExec(`^INSERT INTO \[tags\] \(\[name\]\) VALUES (\(N't\d+'\)(, )?){500}$`).
+		WillReturnResult(sqlmock.NewResult(0, 500))
+	mock.ExpectCommit()
+	n, err = sess.CopyFrom("tags", []string{"name"}, tags)
+	require.NoError(t, err)
+	require.Equal(t, int64(1500), n)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// each statement leaves room below 2100 parameters
+	rows := make([][]interface{}, 1500)
+	for i := range rows {
+		rows[i] = []interface{}{1, 2, 3}
+	}
+	chunk, err := chunkValues(dialect.MSSQL, 0, rows, func(value []interface{}) int {
+		return len(value)
+	})
+	require.NoError(t, err)
+	require.Len(t, chunk, 3)
+	require.Len(t, chunk[0], 666)
+}
@@ -0,0 +1,132 @@
+package dbx
+
+import (
+	"reflect"
+	"strings"
+)
+
+// namedArg returns the map or the struct holding named values,
+// or false if value is a positional value.
+func namedArg(value interface{}) (reflect.Value, bool) {
+	if value == nil {
+		return reflect.Value{}, false
+	}
+	if _, ok := value.(Builder); ok {
+		return reflect.Value{}, false
+	}
+	v := reflect.ValueOf(value)
+	if v.Type().Implements(typeValuer) {
+		return reflect.Value{}, false
+	}
+	if v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		return v, v.Type().Key().Kind() == reflect.String
+	case reflect.Struct:
+		return v, v.Type() != typeTime
+	}
+	return reflect.Value{}, false
+}
+
+func isNameStart(b byte) bool {
+	return b == '_' || isUpper(b) || isLower(b)
+}
+
+// parseNamed replaces `:name` and `@name` in query with placeholders, and
+// returns the names in order. Names in quotes and comments, and `::` casts
+// are left as they are.
+// It returns false if query has positional placeholders or no names at all.
+func parseNamed(query string) (string, []string, bool) {
+	var buf strings.Builder
+	var name []string
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := strings.IndexByte(query[i+1:], c)
+			if end == -1 {
+				buf.WriteString(query[i:])
+				i = len(query)
+				continue
+			}
+			buf.WriteString(query[i : i+end+2])
+			i += end + 1
+			continue
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end == -1 {
+				end = len(query) - i
+			}
+			buf.WriteString(query[i : i+end])
+			i += end - 1
+			continue
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i:], "*/")
+			if end == -1 {
+				buf.WriteString(query[i:])
+				i = len(query)
+				continue
+			}
+			buf.WriteString(query[i : i+end+2])
+			i += end + 1
+			continue
+		case c == '?':
+			if !strings.HasPrefix(query[i:], escapedPlaceholder) {
+				return query, nil, false
+			}
+			buf.WriteString(escapedPlaceholder)
+			i++
+			continue
+		case c == ':' && strings.HasPrefix(query[i:], "::"):
+			buf.WriteString("::")
+			i++
+			continue
+		case (c == ':' || c == '@') && i+1 < len(query) && isNameStart(query[i+1]):
+			if i > 0 && (query[i-1] == '@' || isNameStart(query[i-1]) || isDigit(query[i-1])) {
+				// like `@@version` or `a:b`
+				break
+			}
+			end := i + 1
+			for end < len(query) && (isNameStart(query[end]) || isDigit(query[end])) {
+				end++
+			}
+			name = append(name, query[i+1:end])
+			buf.WriteString(placeholder)
+			i = end - 1
+			continue
+		}
+		buf.WriteByte(c)
+	}
+	if len(name) == 0 {
+		return query, nil, false
+	}
+	return buf.String(), name, true
+}
+
+// bindNamed finds the value of each name in a map or a struct.
+// Struct fields are matched by `db` tag like in Record.
+func bindNamed(arg reflect.Value, name []string) ([]interface{}, error) {
+	value := make([]interface{}, len(name))
+	switch arg.Kind() {
+	case reflect.Map:
+		for i, n := range name {
+			v := arg.MapIndex(reflect.ValueOf(n).Convert(arg.Type().Key()))
+			if !v.IsValid() {
+				return nil, ErrNamedValueNotFound
+			}
+			value[i] = v.Interface()
+		}
+	case reflect.Struct:
+		found := make([]interface{}, len(name))
+		newTagStore().findValueByName(arg, name, found, false)
+		for i, v := range found {
+			if v == nil {
+				return nil, ErrNamedValueNotFound
+			}
+			value[i] = v.(reflect.Value).Interface()
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,67 @@
+package dbx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gokit/dbx/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamedExpr(t *testing.T) {
+	type filter struct {
+		UserID int64 `db:"uid"`
+		Status string
+		Since  time.Time
+	}
+	since := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, test := range []struct {
+		builder Builder
+		d       Dialect
+		want    string
+	}{
+		{
+			builder: Expr("a = :a OR b = :a AND c = @c", map[string]interface{}{"a": 1, "c": "x"}),
+			d:       dialect.MySQL,
+			want:    "a = 1 OR b = 1 AND c = 'x'",
+		},
+		{
+			builder: Expr("created_at::date = :day::date AND id IN :ids AND tags @> '{a}'", map[string]interface{}{"day": "2020-01-02", "ids": []int{1, 2}}),
+			d:       dialect.PostgreSQL,
+			want:    "created_at::date = '2020-01-02'::date AND id IN (1,2) AND tags @> '{a}'",
+		},
+		{
+			builder: Expr("uid = :uid AND status = :status AND created_at > :since", filter{UserID: 1, Status: "on", Since: since}),
+			d:       dialect.SQLite3,
+			want:    "uid = 1 AND status = 'on' AND created_at > '2020-01-02 03:04:05.000000'",
+		},
+		{
+			builder: Expr("note = ':skip' /* :skip */ AND x = :x -- :skip\nAND t = '10:30'", &struct{ X int }{X: 2}),
+			d:       dialect.MySQL,
+			want:    "note = ':skip' /* :skip */ AND x = 2 -- :skip\nAND t = '10:30'",
+		},
+		{
+			builder: Expr("created_at > ?", since),
+			d:       dialect.MySQL,
+			want:    "created_at > '2020-01-02 03:04:05.000000'",
+		},
+		{
+			builder: Select("*").From("users").Where("name = :name OR nick = :name", map[string]interface{}{"name": "bob"}),
+			d:       dialect.MySQL,
+			want:    "SELECT * FROM `users` WHERE name = 'bob' OR nick = 'bob'",
+		},
+		{
+			builder: SelectBySql("SELECT @@version, :v", map[string]interface{}{"v": 1}),
+			d:       dialect.MySQL,
+			want:    "SELECT @@version, 1",
+		},
+	} {
+		s, err := ToRawSql(test.d, test.builder)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+
+	_, err := ToRawSql(dialect.MySQL, Expr("a = :a AND b = :b", map[string]interface{}{"a": 1}))
+	require.Equal(t, ErrNamedValueNotFound, err)
+}
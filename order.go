@@ -9,16 +9,33 @@ const (
 	desc           = true
 )
 
+// orderBy is a column or an expression in ORDER BY with its direction.
+type orderBy struct {
+	column string
+	expr   Builder
+	dir    direction
+}
+
 func order(column string, dir direction) Builder {
-	return BuildFunc(func(d Dialect, buf Buffer) error {
-		// FIXME: no quote ident
-		buf.WriteString(column)
-		switch dir {
-		case asc:
-			buf.WriteString(" ASC")
-		case desc:
-			buf.WriteString(" DESC")
-		}
-		return nil
-	})
+	return &orderBy{column: column, dir: dir}
+}
+
+func orderExpr(expr Builder, dir direction) Builder {
+	return &orderBy{expr: expr, dir: dir}
+}
+
+func (o *orderBy) Build(d Dialect, buf Buffer) error {
+	if o.expr != nil {
+		buf.WriteString(placeholder)
+		buf.WriteValue(o.expr)
+	} else {
+		buildColumn(d, buf, o.column)
+	}
+	switch o.dir {
+	case asc:
+		buf.WriteString(" ASC")
+	case desc:
+		buf.WriteString(" DESC")
+	}
+	return nil
 }
@@ -0,0 +1,317 @@
+package dbx
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"strings"
+)
+
+// defaultMaxAllowedPacket is max_allowed_packet of MySQL 8 by default.
+const defaultMaxAllowedPacket = 4 << 20
+
+// SetMaxAllowedPacket limits the size of a MySQL INSERT with many rows
+// by sessions created later. It should not be larger than max_allowed_packet
+// of the server, which is 4MB by default.
+func (conn *Connection) SetMaxAllowedPacket(n int) *Connection {
+	conn.stmtMu.Lock()
+	defer conn.stmtMu.Unlock()
+	conn.maxAllowedPacket = n
+	return conn
+}
+
+// MaxAllowedPacket limits the size of a MySQL INSERT with many rows,
+// instead of the limit of the Connection.
+func (b *InsertStmt) MaxAllowedPacket(n int) *InsertStmt {
+	b.maxAllowedPacket = n
+	return b
+}
+
+// maxParams is the number of bind parameters allowed in a statement.
+func maxParams(d Dialect) int {
+	switch d.DriverName() {
+	case "sqlite":
+		// SQLITE_MAX_VARIABLE_NUMBER before 3.32.0
+		return 999
+	case "postgres", "mysql":
+		return 65535
+	case "mssql":
+		// 2100 in a statement, leaving room for the rest of it
+		return 2000
+	default:
+		return 2100
+	}
+}
+
+// maxRows is the number of rows allowed in a statement, or 0 if unlimited.
+func maxRows(d Dialect) int {
+	if d.DriverName() == "mssql" {
+		// the row value expressions of a table value constructor like VALUES
+		return 1000
+	}
+	return 0
+}
+
+// Records adds a tuple for columns from each struct in a slice,
+// like Record. The slice can be of structs or pointers to structs.
+//
+// If the rows exceed the limits of the dialect, they are inserted
+// by several statements. RowsAffected of the result is the sum of them.
+// Keys are set like in Record. With LastInsertId, they are only set
+// if the dialect tells the first ID of each statement.
+func (b *InsertStmt) Records(slice interface{}) *InsertStmt {
+	v := reflect.Indirect(reflect.ValueOf(slice))
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return b
+	}
+	for i := 0; i < v.Len(); i++ {
+		elem := reflect.Indirect(v.Index(i))
+		if elem.Kind() == reflect.Struct {
+			b.addRecord(elem)
+		}
+	}
+	return b
+}
+
+// InTransaction wraps the statements of a chunked insert in a transaction,
+// so that either all or none of the rows are inserted.
+// It has no effect if the InsertStmt is created by Tx.
+func (b *InsertStmt) InTransaction() *InsertStmt {
+	b.inTx = true
+	return b
+}
+
+// chunk splits Value so that each statement is within the limits of d.
+func (b *InsertStmt) chunk(d Dialect) ([][][]interface{}, error) {
+	return chunkValues(d, b.maxAllowedPacket, b.Value, func(value []interface{}) int {
+		return len(value)
+	})
+}
+
+// chunkValues splits rows of values so that each statement is within the limits of d.
+// params tells the number of bind parameters for a row.
+// In MySQL, the size of a row is estimated as an interpolated tuple,
+// and a statement is limited to maxPacket bytes, or 4MB if it is 0.
+func chunkValues(d Dialect, maxPacket int, rows [][]interface{}, params func(value []interface{}) int) ([][][]interface{}, error) {
+	isMySQL := d.DriverName() == "mysql"
+	if maxPacket <= 0 {
+		maxPacket = defaultMaxAllowedPacket
+	}
+	limit := maxRows(d)
+
+	var chunk [][][]interface{}
+	start, n, size := 0, 0, 0
+	for i, value := range rows {
+		rowSize := 0
+		if isMySQL {
+			tuple := "(" + strings.TrimSuffix(strings.Repeat(placeholder+",", len(value)), ",") + ")"
+			s, err := InterpolateForDialect(tuple, value, d)
+			if err != nil {
+				return nil, err
+			}
+			rowSize = len(s) + len(", ")
+		}
+		rowParams := params(value)
+		// leave room for the rest of the statement
+		if i > start && (n+rowParams > maxParams(d) || size+rowSize > maxPacket-4096 ||
+			limit > 0 && i-start >= limit) {
+			chunk = append(chunk, rows[start:i])
+			start, n, size = i, 0, 0
+		}
+		n += rowParams
+		size += rowSize
+	}
+	return append(chunk, rows[start:]), nil
+}
+
+// chunkRunner returns the runner for statements of chunks.
+// With inTx, a Session begins a transaction for more than one chunk,
+// which must be committed by the caller.
+func chunkRunner(ctx context.Context, r runner, inTx bool, chunks int) (runner, *Tx, error) {
+	sess, ok := r.(*Session)
+	if !ok || !inTx || chunks <= 1 {
+		return r, nil, nil
+	}
+	tx, err := sess.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, tx, nil
+}
+
+// chunkResult sums up the results of the statements of chunks.
+type chunkResult struct {
+	lastInsertID int64
+	rowsAffected int64
+	idErr        error
+	rowsErr      error
+}
+
+func (r *chunkResult) LastInsertId() (int64, error) {
+	return r.lastInsertID, r.idErr
+}
+
+func (r *chunkResult) RowsAffected() (int64, error) {
+	return r.rowsAffected, r.rowsErr
+}
+
+func (b *InsertStmt) execChunk(ctx context.Context, chunk [][][]interface{}) (sql.Result, error) {
+	runner, tx, err := chunkRunner(ctx, b.runner, b.inTx, len(chunk))
+	if err != nil {
+		return nil, err
+	}
+	if tx != nil {
+		defer tx.RollbackUnlessCommitted()
+	}
+
+	result := &chunkResult{}
+	offset := 0
+	for _, value := range chunk {
+		stmt := *b
+		stmt.Value = value
+
+		if b.returnsKey() {
+			stmt.ReturnColumn = b.keyColumn
+			n, err := b.scanKeys(ctx, runner, &stmt, b.recordKeys[offset:offset+len(value)])
+			if err != nil {
+				return nil, err
+			}
+			result.rowsAffected += n
+			result.idErr = ErrNotSupported
+			offset += len(value)
+			continue
+		}
+
+		r, err := exec(ctx, runner, b.EventReceiver, &stmt, b.Dialect)
+		if err != nil {
+			return nil, err
+		}
+
+		id, idErr := r.LastInsertId()
+		if idErr == nil {
+			result.lastInsertID = id
+		} else {
+			result.idErr = idErr
+		}
+		n, rowsErr := r.RowsAffected()
+		if rowsErr == nil {
+			result.rowsAffected += n
+		} else {
+			result.rowsErr = rowsErr
+		}
+		if idErr == nil && rowsErr == nil {
+			b.setRecordIDs(offset, len(value), n, id)
+		}
+		offset += len(value)
+	}
+
+	if tx != nil {
+		err := tx.Commit()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if b.RecordID != nil {
+		if result.idErr == nil {
+			*b.RecordID = result.lastInsertID
+		}
+		b.RecordID = nil
+	}
+	b.recordKeys = nil
+	return result, nil
+}
+
+// returnsKey tells whether keys of records are scanned from `RETURNING`.
+// `OUTPUT` of SQL Server is not used, as its rows cannot be matched
+// with records by order.
+// If some rows might be skipped, keys cannot be matched with records.
+func (b *InsertStmt) returnsKey() bool {
+	if b.Dialect.DriverName() != "postgres" {
+		return false
+	}
+	if b.noReturnKey || len(b.keyColumn) == 0 || len(b.ReturnColumn) > 0 ||
+		b.Ignored || b.conflict != nil {
+		return false
+	}
+	for _, key := range b.recordKeys {
+		for _, ptr := range key {
+			if ptr != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// scanKeys inserts the rows of stmt, and scans the returned keys into records
+// in order, assuming the rows are returned in the order of VALUES.
+func (b *InsertStmt) scanKeys(ctx context.Context, runner runner, stmt *InsertStmt, key [][]interface{}) (int64, error) {
+	timeout := runner.GetTimeout()
+	if timeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	query, rows, err := queryRows(ctx, runner, b.EventReceiver, stmt, b.Dialect)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var n int64
+	for rows.Next() {
+		var dest []interface{}
+		if n < int64(len(key)) {
+			dest = key[n]
+		}
+		if dest == nil {
+			dest = make([]interface{}, len(b.keyColumn))
+		}
+		for i := range dest {
+			if dest[i] == nil {
+				dest[i] = new(interface{})
+			}
+		}
+		err := rows.Scan(dest...)
+		if err != nil {
+			return 0, b.EventErrKv("dbx.insert.scan", err, kvs{
+				"sql": query,
+			})
+		}
+		n++
+	}
+	return n, rows.Err()
+}
+
+// setRecordIDs sets the integer keys of n rows inserted from offset.
+// MySQL returns the first ID of a multiple-row insert, and SQLite3 the last.
+// Keys are not set if some rows might be skipped.
+func (b *InsertStmt) setRecordIDs(offset, n int, affected, id int64) {
+	if b.noReturnKey || b.Ignored || b.conflict != nil || affected != int64(n) || len(b.keyColumn) != 1 || offset >= len(b.recordKeys) {
+		return
+	}
+	var first int64
+	switch b.Dialect.DriverName() {
+	case "mysql":
+		first = id
+	case "sqlite":
+		first = id - int64(n) + 1
+	default:
+		return
+	}
+	for i := 0; i < n && offset+i < len(b.recordKeys); i++ {
+		key := b.recordKeys[offset+i]
+		if key == nil || key[0] == nil {
+			continue
+		}
+		v := reflect.ValueOf(key[0]).Elem()
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v.SetInt(first + int64(i))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			v.SetUint(uint64(first + int64(i)))
+		}
+	}
+}
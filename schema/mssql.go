@@ -0,0 +1,1162 @@
+package schema
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/friendsofgo/errors"
+	"github.com/gokit/dbx/schema/constraint"
+	"github.com/gokit/dbx/utils"
+	"strings"
+)
+
+type mssqlColumnInfo struct {
+	Name       string         `db:"column_name"`
+	DataType   string         `db:"data_type"`
+	Length     sql.NullInt64  `db:"size"`
+	Precision  sql.NullInt64  `db:"numeric_precision"`
+	Scale      sql.NullInt64  `db:"numeric_scale"`
+	Nullable   bool           `db:"is_nullable"`
+	Default    sql.NullString `db:"column_default"`
+	PrimaryKey bool           `db:"is_pkey"`
+	AutoInc    bool           `db:"is_autoinc"`
+	Collation  sql.NullString `db:"collation_name"`
+	Comment    sql.NullString `db:"column_comment"`
+}
+
+type mssqlTableInfo struct {
+	Name    string         `db:"table_name"`
+	Schema  string         `db:"table_schema"`
+	Comment sql.NullString `db:"table_comment"`
+}
+
+type mssql struct {
+	query Query
+}
+
+func (d mssql) QuoteIdent(s string) string {
+	return utils.QuoteIdentBrackets(s)
+}
+
+// quote a string as an unicode literal
+func (d mssql) quoteString(s string) string {
+	return "N'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+// quote strings as unicode literals separated by comma
+func (d mssql) quoteStrings(values ...string) string {
+	for i, value := range values {
+		values[i] = d.quoteString(value)
+	}
+	return strings.Join(values, ",")
+}
+
+// the schema of the table, dbo by default
+func (d mssql) tableSchema(table Table) string {
+	if table.Schema() != "" {
+		return table.Schema()
+	}
+	return "dbo"
+}
+
+// the name of the table, temporary tables are prefixed with #
+func (d mssql) tableName(table Table) string {
+	name := table.Prefix() + table.Name()
+	if table.IsTemporary() {
+		name = "#" + name
+	}
+	return name
+}
+
+// wrap table name, temporary tables are prefixed with #
+func (d mssql) wrapTableName(table Table) string {
+	if !table.IsTemporary() {
+		return wrapTableName(table, d.QuoteIdent)
+	}
+	return d.QuoteIdent(d.tableName(table))
+}
+
+// Create the column definition for a char type.
+func (d mssql) TypeChar(column Column) (string, error) {
+	return fmt.Sprintf("nchar(%d)", column.Length()), nil
+}
+
+// Create the column definition for a string type.
+func (d mssql) TypeString(column Column) (string, error) {
+	return fmt.Sprintf("nvarchar(%d)", column.Length()), nil
+}
+
+// Create the column definition for a text type.
+func (d mssql) TypeText(column Column) (string, error) {
+	return "nvarchar(max)", nil
+}
+
+// Create the column definition for a medium text type.
+func (d mssql) TypeMediumText(column Column) (string, error) {
+	return "nvarchar(max)", nil
+}
+
+// Create the column definition for a long text type.
+func (d mssql) TypeLongText(column Column) (string, error) {
+	return "nvarchar(max)", nil
+}
+
+// Create the column definition for a big integer type.
+func (d mssql) TypeBigInteger(column Column) (string, error) {
+	return "bigint", nil
+}
+
+// Create the column definition for an integer type.
+func (d mssql) TypeInteger(column Column) (string, error) {
+	return "int", nil
+}
+
+// Create the column definition for a medium integer type.
+func (d mssql) TypeMediumInteger(column Column) (string, error) {
+	return "int", nil
+}
+
+// Create the column definition for a tiny integer type.
+func (d mssql) TypeTinyInteger(column Column) (string, error) {
+	return "tinyint", nil
+}
+
+// Create the column definition for a small integer type.
+func (d mssql) TypeSmallInteger(column Column) (string, error) {
+	return "smallint", nil
+}
+
+// Create the column definition for a tiny blob type.
+func (d mssql) TypeTinyBlob(column Column) (string, error) {
+	return "varbinary(255)", nil
+}
+
+// Create the column definition for an blob type.
+func (d mssql) TypeBlob(column Column) (string, error) {
+	return "varbinary(max)", nil
+}
+
+// Create the column definition for a medium blob type.
+func (d mssql) TypeMediumBlob(column Column) (string, error) {
+	return "varbinary(max)", nil
+}
+
+// Create the column definition for a long blob type.
+func (d mssql) TypeLongBlob(column Column) (string, error) {
+	return "varbinary(max)", nil
+}
+
+// Create the column definition for a float type.
+func (d mssql) TypeFloat(column Column) (string, error) {
+	if column.Precision() > 0 {
+		return fmt.Sprintf("float(%d)", column.Precision()), nil
+	}
+	return "float", nil
+}
+
+// Create the column definition for a double type.
+func (d mssql) TypeDouble(column Column) (string, error) {
+	return "float", nil
+}
+
+// Create the column definition for a decimal type.
+func (d mssql) TypeDecimal(column Column) (string, error) {
+	return fmt.Sprintf("decimal(%d, %d)", column.Precision(), column.Scale()), nil
+}
+
+// Create the column definition for a boolean type.
+func (d mssql) TypeBoolean(column Column) (string, error) {
+	return "bit", nil
+}
+
+// Create the column definition for an enumeration type.
+func (d mssql) TypeEnum(column Column) (string, error) {
+	var values []string
+	for _, value := range column.AllowedValues() {
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	return fmt.Sprintf("nvarchar(255) check (%s in (%s))", d.QuoteIdent(column.Name()), d.quoteStrings(values...)), nil
+}
+
+// Create the column definition for a set enumeration type.
+func (d mssql) TypeSet(column Column) (string, error) {
+	return "nvarchar(255)", nil
+}
+
+// Create the column definition for a json type.
+func (d mssql) TypeJson(column Column) (string, error) {
+	return "nvarchar(max)", nil
+}
+
+// Create the column definition for a jsonb type.
+func (d mssql) TypeJsonb(column Column) (string, error) {
+	return "nvarchar(max)", nil
+}
+
+// Create the column definition for a date type.
+func (d mssql) TypeDate(column Column) (string, error) {
+	return "date", nil
+}
+
+// Create the column definition for a date-time type.
+func (d mssql) TypeDateTime(column Column) (string, error) {
+	if column.Precision() > 0 {
+		return fmt.Sprintf("datetime2(%d)", column.Precision()), nil
+	}
+	return "datetime", nil
+}
+
+// Create the column definition for a date-time (with time zone) type.
+func (d mssql) TypeDateTimeTz(column Column) (string, error) {
+	if column.Precision() > 0 {
+		return fmt.Sprintf("datetimeoffset(%d)", column.Precision()), nil
+	}
+	return "datetimeoffset", nil
+}
+
+// Create the column definition for a time type.
+func (d mssql) TypeTime(column Column) (string, error) {
+	if column.Precision() > 0 {
+		return fmt.Sprintf("time(%d)", column.Precision()), nil
+	}
+	return "time", nil
+}
+
+// Create the column definition for a time (with time zone) type.
+func (d mssql) TypeTimeTz(column Column) (string, error) {
+	return d.TypeTime(column)
+}
+
+// Create the column definition for a timestamp type.
+func (d mssql) TypeTimestamp(column Column) (string, error) {
+	if column.Precision() > 0 {
+		return fmt.Sprintf("datetime2(%d)", column.Precision()), nil
+	}
+	return "datetime", nil
+}
+
+// Create the column definition for a timestamp (with time zone) type.
+func (d mssql) TypeTimestampTz(column Column) (string, error) {
+	return d.TypeDateTimeTz(column)
+}
+
+// Create the column definition for a year type.
+func (d mssql) TypeYear(column Column) (string, error) {
+	return "int", nil
+}
+
+// Create the column definition for a binary type.
+func (d mssql) TypeBinary(column Column) (string, error) {
+	return "varbinary(max)", nil
+}
+
+// Create the column definition for a uuid type.
+func (d mssql) TypeUuid(column Column) (string, error) {
+	return "uniqueidentifier", nil
+}
+
+// Create the column definition for an IP address type.
+func (d mssql) TypeIpAddress(column Column) (string, error) {
+	return "nvarchar(45)", nil
+}
+
+// Create the column definition for a MAC address type.
+func (d mssql) TypeMacAddress(column Column) (string, error) {
+	return "nvarchar(17)", nil
+}
+
+// Create the column definition for a spatial Geometry type.
+func (d mssql) TypeGeometry(column Column) (string, error) {
+	return "geography", nil
+}
+
+// Create the column definition for a spatial Point type.
+func (d mssql) TypePoint(column Column) (string, error) {
+	return "geography", nil
+}
+
+// Create the column definition for a spatial LineString type.
+func (d mssql) TypeLineString(column Column) (string, error) {
+	return "geography", nil
+}
+
+// Create the column definition for a spatial Polygon type.
+func (d mssql) TypePolygon(column Column) (string, error) {
+	return "geography", nil
+}
+
+// Create the column definition for a spatial GeometryCollection type.
+func (d mssql) TypeGeometryCollection(column Column) (string, error) {
+	return "geography", nil
+}
+
+// Create the column definition for a spatial MultiPoint type.
+func (d mssql) TypeMultiPoint(column Column) (string, error) {
+	return "geography", nil
+}
+
+// Create the column definition for a spatial MultiLineString type.
+func (d mssql) TypeMultiLineString(column Column) (string, error) {
+	return "geography", nil
+}
+
+// Create the column definition for a spatial MultiPolygon type.
+func (d mssql) TypeMultiPolygon(column Column) (string, error) {
+	return "geography", nil
+}
+
+// Modify the column
+func (d mssql) ModifyColumn(column Column) string {
+	// 'Collate', 'Nullable', 'Default', 'Increment'
+
+	var b strings.Builder
+
+	if column.Collate() != "" {
+		b.WriteString(" COLLATE ")
+		b.WriteString(column.Collate())
+	}
+
+	if column.Nullable() {
+		b.WriteString(" NULL")
+	} else {
+		b.WriteString(" NOT NULL")
+	}
+
+	if column.UseCurrent() && (column.DataType() == TypeDateTime || column.DataType() == TypeDateTimeTz ||
+		column.DataType() == TypeTimestamp || column.DataType() == TypeTimestampTz) {
+		b.WriteString(" DEFAULT CURRENT_TIMESTAMP")
+	} else if column.DefaultValue() != nil {
+		value := d.defaultValue(column.DefaultValue())
+		if value != "" {
+			b.WriteString(" DEFAULT ")
+			b.WriteString(value)
+		}
+	}
+
+	if column.AutoIncrement() {
+		switch column.DataType() {
+		case TypeInt, TypeBigInt, TypeMediumInt, TypeTinyInt, TypeSmallInt:
+			b.WriteString(" IDENTITY(1,1)")
+		}
+	}
+
+	return b.String()
+}
+
+// get the default value of the column
+func (d mssql) defaultValue(value interface{}) string {
+	if value == nil {
+		return ""
+	} else if value, ok := value.(fmt.Stringer); ok {
+		return value.String()
+	}
+	return d.quoteString(fmt.Sprintf("%v", value))
+}
+
+// Create the column definition, computed columns have no type.
+func (d mssql) columnDefinition(column Column) (string, error) {
+	var b strings.Builder
+
+	b.WriteString(d.QuoteIdent(column.Name()))
+
+	if column.VirtualAs() != "" || column.StoredAs() != "" {
+		b.WriteString(" AS (")
+		if column.StoredAs() != "" {
+			b.WriteString(column.StoredAs())
+			b.WriteString(") PERSISTED")
+		} else {
+			b.WriteString(column.VirtualAs())
+			b.WriteString(")")
+		}
+		return b.String(), nil
+	}
+
+	columnType, err := ColumnType(d, column)
+
+	if err != nil {
+		return "", err
+	}
+
+	b.WriteString(" ")
+	b.WriteString(columnType)
+	b.WriteString(d.ModifyColumn(column))
+
+	return b.String(), nil
+}
+
+// Compile the comment of a table or a column.
+func (d mssql) compileComment(table Table, comment string, columnName ...string) string {
+	var b strings.Builder
+
+	b.WriteString("EXEC sp_addextendedproperty N'MS_Description', ")
+	b.WriteString(d.quoteString(comment))
+	b.WriteString(", N'SCHEMA', ")
+	b.WriteString(d.quoteString(d.tableSchema(table)))
+	b.WriteString(", N'TABLE', ")
+	b.WriteString(d.quoteString(table.Prefix() + table.Name()))
+
+	if len(columnName) > 0 {
+		b.WriteString(", N'COLUMN', ")
+		b.WriteString(d.quoteString(columnName[0]))
+	}
+
+	b.WriteString(";")
+
+	return b.String()
+}
+
+// Compile the query to determine the list of tables.
+func (d mssql) CompileTableExists(tableName string, tableSchema ...string) (string, error) {
+	var b strings.Builder
+
+	b.WriteString("select count(*) from information_schema.tables where table_name = ")
+	b.WriteString(d.quoteString(tableName))
+
+	if len(tableSchema) > 0 {
+		b.WriteString(" and table_schema = ")
+		b.WriteString(d.quoteString(tableSchema[0]))
+	}
+
+	b.WriteString(" and table_type = 'BASE TABLE'")
+
+	return b.String(), nil
+}
+
+// Compile the query to determine the list of columns.
+func (d mssql) CompileColumnListing(tableName string, tableSchema ...string) (string, error) {
+	var b strings.Builder
+
+	b.WriteString("select column_name from information_schema.columns where table_name = ")
+	b.WriteString(d.quoteString(tableName))
+
+	if len(tableSchema) > 0 {
+		b.WriteString(" and table_schema = ")
+		b.WriteString(d.quoteString(tableSchema[0]))
+	}
+
+	return b.String(), nil
+}
+
+// Compile a create table command.
+func (d mssql) CompileCreate(table Table) (string, error) {
+	var b strings.Builder
+
+	b.WriteString("CREATE TABLE ")
+	b.WriteString(d.wrapTableName(table))
+	b.WriteString("(\n")
+
+	// primary key names
+	var pkNames []string
+
+	columns := table.Columns()
+
+	for i, column := range columns {
+
+		if column.PrimaryKey() {
+			pkNames = append(pkNames, d.QuoteIdent(column.Name()))
+		}
+
+		b.WriteString("\t")
+
+		definition, err := d.columnDefinition(column)
+
+		if err != nil {
+			return "", err
+		}
+
+		b.WriteString(definition)
+
+		if i < len(columns)-1 || len(pkNames) > 0 {
+			b.WriteString(",")
+		}
+
+		b.WriteString("\n")
+	}
+
+	if len(pkNames) > 0 {
+		b.WriteString("\tPRIMARY KEY (")
+		b.WriteString(strings.Join(pkNames, ", "))
+		b.WriteString(")\n")
+	}
+
+	b.WriteString(");")
+
+	// temporary tables have no extended properties
+	if table.IsTemporary() {
+		return b.String(), nil
+	}
+
+	if table.Comment() != "" {
+		b.WriteString("\n")
+		b.WriteString(d.compileComment(table, table.Comment()))
+	}
+
+	for _, column := range columns {
+		if column.Comment() != "" {
+			b.WriteString("\n")
+			b.WriteString(d.compileComment(table, column.Comment(), column.Name()))
+		}
+	}
+
+	return b.String(), nil
+}
+
+// Compile an alter column command, SQL Server renames columns by sp_rename
+// and only changes the type, the collation and the nullability.
+func (d mssql) compileAlterColumn(table Table, column Column) (string, error) {
+	var b strings.Builder
+
+	columnName := column.Name()
+
+	if column.Rename() != "" {
+		b.WriteString("EXEC sp_rename ")
+		b.WriteString(d.quoteString(d.tableSchema(table) + "." + d.tableName(table) + "." + column.Name()))
+		b.WriteString(", ")
+		b.WriteString(d.quoteString(column.Rename()))
+		b.WriteString(", N'COLUMN';\n")
+		columnName = column.Rename()
+	}
+
+	columnType, err := ColumnType(d, column)
+
+	if err != nil {
+		return "", err
+	}
+
+	b.WriteString("ALTER TABLE ")
+	b.WriteString(d.wrapTableName(table))
+	b.WriteString(" ALTER COLUMN ")
+	b.WriteString(d.QuoteIdent(columnName))
+	b.WriteString(" ")
+	b.WriteString(columnType)
+
+	if column.Collate() != "" {
+		b.WriteString(" COLLATE ")
+		b.WriteString(column.Collate())
+	}
+
+	if column.Nullable() {
+		b.WriteString(" NULL")
+	} else {
+		b.WriteString(" NOT NULL")
+	}
+
+	b.WriteString(";")
+
+	return b.String(), nil
+}
+
+// Compile a modify table command.
+func (d mssql) CompileModifyColumns(table Table) (string, error) {
+	var sqls []string
+
+	for _, column := range table.ChangedColumns() {
+		sql, err := d.compileAlterColumn(table, column)
+
+		if err != nil {
+			return "", err
+		}
+
+		sqls = append(sqls, sql)
+	}
+
+	return strings.Join(sqls, "\n"), nil
+}
+
+// Compile a modify column command.
+func (d mssql) CompileModifyColumn(table Table, columnName string) (string, error) {
+	for _, column := range table.ChangedColumns() {
+		if column.Name() == columnName {
+			return d.compileAlterColumn(table, column)
+		}
+	}
+
+	return "", errors.New(fmt.Sprintf("dbx: not found changed column '%s'", columnName))
+}
+
+// Compile add columns.
+func (d mssql) CompileAddColumns(table Table) (string, error) {
+	var b strings.Builder
+
+	b.WriteString("ALTER TABLE ")
+	b.WriteString(d.wrapTableName(table))
+	b.WriteString(" ADD \n")
+
+	columns := table.AddedColumns()
+
+	for i, column := range columns {
+		definition, err := d.columnDefinition(column)
+
+		if err != nil {
+			return "", err
+		}
+
+		b.WriteString(definition)
+
+		if i < len(columns)-1 {
+			b.WriteString(",\n")
+		}
+	}
+
+	b.WriteString(";")
+
+	return b.String(), nil
+}
+
+// Compile add a column.
+func (d mssql) CompileAddColumn(table Table, columnName string) (string, error) {
+	for _, column := range table.AddedColumns() {
+
+		if column.Name() != columnName {
+			continue
+		}
+
+		definition, err := d.columnDefinition(column)
+
+		if err != nil {
+			return "", err
+		}
+
+		var b strings.Builder
+
+		b.WriteString("ALTER TABLE ")
+		b.WriteString(d.wrapTableName(table))
+		b.WriteString(" ADD ")
+		b.WriteString(definition)
+		b.WriteString(";")
+
+		return b.String(), nil
+	}
+
+	return "", errors.New(fmt.Sprintf("dbx: not found added column '%s'", columnName))
+}
+
+// the name of the primary key constraint
+func (d mssql) primaryKeyName(table Table) string {
+	return table.Prefix() + table.Name() + "_pkey"
+}
+
+// Compile a primary key command.
+func (d mssql) CompilePrimaryKey(table Table, columnNames ...string) (string, error) {
+	var b strings.Builder
+
+	b.WriteString("ALTER TABLE ")
+	b.WriteString(d.wrapTableName(table))
+	b.WriteString(" ADD CONSTRAINT ")
+	b.WriteString(d.QuoteIdent(d.primaryKeyName(table)))
+	b.WriteString(" PRIMARY KEY (")
+	b.WriteString(utils.QuoteIdents(columnNames, d.QuoteIdent))
+	b.WriteString(");")
+
+	return b.String(), nil
+}
+
+// Compile a drop primary key command.
+func (d mssql) CompileDropPrimaryKey(table Table) (string, error) {
+	var b strings.Builder
+
+	b.WriteString("ALTER TABLE ")
+	b.WriteString(d.wrapTableName(table))
+	b.WriteString(" DROP CONSTRAINT ")
+	b.WriteString(d.QuoteIdent(d.primaryKeyName(table)))
+	b.WriteString(";")
+
+	return b.String(), nil
+}
+
+// Compile an index creation command.
+func (d mssql) CompileIndex(table Table, index Index) (string, error) {
+	var b strings.Builder
+
+	switch index.Type() {
+	case NormalIndex:
+		b.WriteString("CREATE INDEX ")
+	case UniqueIndex:
+		b.WriteString("CREATE UNIQUE INDEX ")
+	case SpatialIndex, GistIndex:
+		b.WriteString("CREATE SPATIAL INDEX ")
+	default:
+		return "", errors.New(fmt.Sprintf("mssql not support index type '%s'", index.Type()))
+	}
+
+	b.WriteString(d.QuoteIdent(index.Name()))
+	b.WriteString(" ON ")
+	b.WriteString(d.wrapTableName(table))
+	b.WriteString(" (")
+	b.WriteString(utils.QuoteIdents(index.ColumnNames(), d.QuoteIdent))
+	b.WriteString(");")
+
+	return b.String(), nil
+}
+
+// Compile a drop index command.
+func (d mssql) CompileDropIndex(table Table, indexName string) (string, error) {
+	var b strings.Builder
+	b.WriteString("DROP INDEX ")
+	b.WriteString(d.QuoteIdent(indexName))
+	b.WriteString(" ON ")
+	b.WriteString(d.wrapTableName(table))
+	b.WriteString(";")
+	return b.String(), nil
+}
+
+// Compile a drop unique index command.
+func (d mssql) CompileDropUnique(table Table, indexName string) (string, error) {
+	return d.CompileDropIndex(table, indexName)
+}
+
+// Compile a drop spatial index command.
+func (d mssql) CompileDropSpatialIndex(table Table, indexName string) (string, error) {
+	return d.CompileDropIndex(table, indexName)
+}
+
+// Compile a drop foreign index command.
+func (d mssql) CompileDropForeign(table Table, indexName string) (string, error) {
+	var b strings.Builder
+	b.WriteString("ALTER TABLE ")
+	b.WriteString(d.wrapTableName(table))
+	b.WriteString(" DROP CONSTRAINT ")
+	b.WriteString(d.QuoteIdent(indexName))
+	b.WriteString(";")
+	return b.String(), nil
+}
+
+// Compile a drop table command.
+func (d mssql) CompileDrop(tableName string) (string, error) {
+	var b strings.Builder
+	b.WriteString("DROP TABLE ")
+	b.WriteString(d.QuoteIdent(tableName))
+	b.WriteString(";")
+	return b.String(), nil
+}
+
+// Compile a drop table (if exists) command.
+func (d mssql) CompileDropIfExists(tableName string) (string, error) {
+	var b strings.Builder
+	b.WriteString("DROP TABLE IF EXISTS ")
+	b.WriteString(d.QuoteIdent(tableName))
+	b.WriteString(";")
+	return b.String(), nil
+}
+
+// Compile a drop column command.
+func (d mssql) CompileDropColumn(table Table, columnNames []string) (string, error) {
+	var b strings.Builder
+
+	b.WriteString("ALTER TABLE ")
+	b.WriteString(d.wrapTableName(table))
+	b.WriteString(" DROP COLUMN ")
+	b.WriteString(utils.QuoteIdents(columnNames, d.QuoteIdent))
+	b.WriteString(";")
+
+	return b.String(), nil
+}
+
+// Compile a rename table command.
+func (d mssql) CompileRenameTable(table Table, toName string) (string, error) {
+	var b strings.Builder
+
+	b.WriteString("EXEC sp_rename ")
+	b.WriteString(d.quoteString(d.tableSchema(table) + "." + d.tableName(table)))
+	b.WriteString(", ")
+	b.WriteString(d.quoteString(toName))
+	b.WriteString(";")
+
+	return b.String(), nil
+}
+
+// Compile a rename index command.
+func (d mssql) CompileRenameIndex(table Table, from string, to string) (string, error) {
+	var b strings.Builder
+
+	b.WriteString("EXEC sp_rename ")
+	b.WriteString(d.quoteString(d.tableSchema(table) + "." + d.tableName(table) + "." + from))
+	b.WriteString(", ")
+	b.WriteString(d.quoteString(to))
+	b.WriteString(", N'INDEX';")
+
+	return b.String(), nil
+}
+
+// Compile the SQL needed to drop all tables.
+func (d mssql) CompileDropAllTables(tableNames ...string) (string, error) {
+	var b strings.Builder
+
+	b.WriteString("DROP TABLE ")
+	b.WriteString(utils.QuoteIdents(tableNames, d.QuoteIdent))
+	b.WriteString(";")
+
+	return b.String(), nil
+}
+
+// Compile the SQL needed to drop all views.
+func (d mssql) CompileDropAllViews(viewNames ...string) (string, error) {
+	var b strings.Builder
+
+	b.WriteString("DROP VIEW ")
+	b.WriteString(utils.QuoteIdents(viewNames, d.QuoteIdent))
+	b.WriteString(";")
+
+	return b.String(), nil
+}
+
+// Compile the SQL needed to drop all types.
+func (d mssql) CompileDropAllTypes(typeNames ...string) (string, error) {
+	return "", errors.New("mssql not support 'CompileDropAllTypes'")
+}
+
+// Compile the SQL needed to retrieve the names of a type of tables.
+func (d mssql) compileGetAllTables(tableType string, schemaNames ...string) string {
+	var b strings.Builder
+
+	b.WriteString("select table_name from information_schema.tables where table_type = ")
+	b.WriteString(d.quoteString(tableType))
+
+	if len(schemaNames) > 0 {
+		b.WriteString(" and table_schema in (")
+		b.WriteString(d.quoteStrings(append([]string{}, schemaNames...)...))
+		b.WriteString(")")
+	}
+
+	return b.String()
+}
+
+// Compile the SQL needed to retrieve all table names.
+func (d mssql) CompileGetAllTables(schemaNames ...string) (string, error) {
+	return d.compileGetAllTables("BASE TABLE", schemaNames...), nil
+}
+
+// Compile the SQL needed to retrieve all view names.
+func (d mssql) CompileGetAllViews(schemaNames ...string) (string, error) {
+	return d.compileGetAllTables("VIEW", schemaNames...), nil
+}
+
+// Compile the SQL needed to retrieve all type names.
+func (d mssql) CompileGetAllTypes() (string, error) {
+	return "", errors.New("mssql not support 'CompileGetAllTypes'")
+}
+
+// Compile the SQL needed to rebuild the database. [SQLite]
+func (d mssql) CompileRebuild() (string, error) {
+	return "", errors.New("mssql not support 'CompileRebuild'")
+}
+
+// Compile the command to enable foreign key constraints.
+func (d mssql) CompileEnableForeignKeyConstraints() (string, error) {
+	return `EXEC sp_msforeachtable @command1 = 'ALTER TABLE ? WITH CHECK CHECK CONSTRAINT all';`, nil
+}
+
+// Compile the command to disable foreign key constraints.
+func (d mssql) CompileDisableForeignKeyConstraints() (string, error) {
+	return `EXEC sp_msforeachtable @command1 = 'ALTER TABLE ? NOCHECK CONSTRAINT all';`, nil
+}
+
+// Convert the column type of SQL Server to abstract data type
+func (d mssql) dataType(info mssqlColumnInfo) (DataType, error) {
+	switch strings.ToLower(info.DataType) {
+	case "nchar":
+		return TypeChar, nil
+	case "nvarchar", "varchar":
+		if info.Length.Valid && info.Length.Int64 == -1 {
+			return TypeText, nil
+		}
+		return TypeString, nil
+	case "ntext", "xml":
+		return TypeText, nil
+	case "bit":
+		return TypeBoolean, nil
+	case "real":
+		return TypeFloat, nil
+	case "numeric", "money", "smallmoney":
+		return TypeDecimal, nil
+	case "datetime2", "smalldatetime":
+		return TypeDateTime, nil
+	case "datetimeoffset":
+		return TypeDateTimeTz, nil
+	case "uniqueidentifier":
+		return TypeUUID, nil
+	case "varbinary", "image":
+		return TypeBlob, nil
+	case "geography":
+		return TypeGeometry, nil
+	}
+	return DataTypeMapper(info.DataType)
+}
+
+// Parse the default value of a column, which is enclosed with parentheses like ((0)) or (N'abc')
+func (d mssql) parseDefault(column *ColumnSchema, value string) {
+	for strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		value = value[1 : len(value)-1]
+	}
+
+	switch strings.ToLower(value) {
+	case "getdate()", "current_timestamp", "sysdatetime()":
+		column.defaultValue = utils.Express("CURRENT_TIMESTAMP")
+		column.useCurrent = true
+		return
+	}
+
+	value = strings.TrimPrefix(value, "N")
+	if strings.HasPrefix(value, "'") && strings.HasSuffix(value, "'") && len(value) >= 2 {
+		value = strings.Replace(value[1:len(value)-1], "''", "'", -1)
+	}
+
+	column.defaultValue = value
+}
+
+// Load table columns from the database
+func (d mssql) LoadColumns(tableName string, tableSchema ...string) ([]Column, error) {
+	var sql strings.Builder
+
+	sql.WriteString(`
+SELECT
+    c.COLUMN_NAME AS column_name,
+    c.DATA_TYPE AS data_type,
+    c.CHARACTER_MAXIMUM_LENGTH AS size,
+    c.NUMERIC_PRECISION AS numeric_precision,
+    c.NUMERIC_SCALE AS numeric_scale,
+    CAST(CASE WHEN c.IS_NULLABLE = 'YES' THEN 1 ELSE 0 END AS bit) AS is_nullable,
+    c.COLUMN_DEFAULT AS column_default,
+    CAST(CASE WHEN pk.COLUMN_NAME IS NULL THEN 0 ELSE 1 END AS bit) AS is_pkey,
+    CAST(COLUMNPROPERTY(OBJECT_ID(QUOTENAME(c.TABLE_SCHEMA) + '.' + QUOTENAME(c.TABLE_NAME)), c.COLUMN_NAME, 'IsIdentity') AS bit) AS is_autoinc,
+    c.COLLATION_NAME AS collation_name,
+    CAST(ep.value AS nvarchar(max)) AS column_comment
+FROM INFORMATION_SCHEMA.COLUMNS AS c
+LEFT JOIN (
+    SELECT kcu.TABLE_SCHEMA, kcu.TABLE_NAME, kcu.COLUMN_NAME
+    FROM INFORMATION_SCHEMA.TABLE_CONSTRAINTS AS tc
+    INNER JOIN INFORMATION_SCHEMA.KEY_COLUMN_USAGE AS kcu
+        ON kcu.CONSTRAINT_SCHEMA = tc.CONSTRAINT_SCHEMA AND kcu.CONSTRAINT_NAME = tc.CONSTRAINT_NAME
+    WHERE tc.CONSTRAINT_TYPE = 'PRIMARY KEY'
+) AS pk
+    ON pk.TABLE_SCHEMA = c.TABLE_SCHEMA AND pk.TABLE_NAME = c.TABLE_NAME AND pk.COLUMN_NAME = c.COLUMN_NAME
+LEFT JOIN sys.extended_properties AS ep
+    ON ep.major_id = OBJECT_ID(QUOTENAME(c.TABLE_SCHEMA) + '.' + QUOTENAME(c.TABLE_NAME))
+    AND ep.minor_id = COLUMNPROPERTY(ep.major_id, c.COLUMN_NAME, 'ColumnId')
+    AND ep.name = 'MS_Description'
+WHERE c.TABLE_NAME = @p1`)
+
+	var args = []interface{}{tableName}
+
+	if len(tableSchema) > 0 {
+		sql.WriteString(" AND c.TABLE_SCHEMA = @p2")
+		args = append(args, tableSchema[0])
+	}
+
+	sql.WriteString(" ORDER BY c.ORDINAL_POSITION ASC")
+
+	var columnInfos []mssqlColumnInfo
+
+	_, err := d.query(sql.String(), &columnInfos, args...)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("dbx: error on query columns of the table %s", d.quoteString(tableName)))
+	}
+
+	var columns []Column
+
+	for _, info := range columnInfos {
+
+		dataType, err := d.dataType(info)
+
+		if err != nil {
+			return nil, err
+		}
+
+		column := newColumn(info.Name, dataType)
+
+		// not added
+		column.added = false
+
+		column.nullable = info.Nullable
+
+		if info.Length.Valid && info.Length.Int64 > 0 {
+			column.length = int(info.Length.Int64)
+		}
+
+		if info.Precision.Valid {
+			column.precision = int(info.Precision.Int64)
+		}
+
+		if info.Scale.Valid {
+			column.scale = int(info.Scale.Int64)
+		}
+
+		if info.Default.Valid {
+			d.parseDefault(column, info.Default.String)
+		}
+
+		if info.Collation.Valid {
+			column.collate = info.Collation.String
+		}
+
+		if info.Comment.Valid {
+			column.comment = info.Comment.String
+		}
+
+		column.primaryKey = info.PrimaryKey
+		column.autoIncrement = info.AutoInc
+
+		// save old column
+		column.store()
+
+		columns = append(columns, column)
+	}
+
+	return columns, nil
+}
+
+// Load table from the database
+func (d mssql) LoadTable(tableName string, tableSchema ...string) (Table, error) {
+	var sql strings.Builder
+
+	sql.WriteString("select t.TABLE_SCHEMA as table_schema, t.TABLE_NAME as table_name, cast(ep.value as nvarchar(max)) as table_comment")
+	sql.WriteString(" from INFORMATION_SCHEMA.TABLES as t")
+	sql.WriteString(" left join sys.extended_properties as ep on ep.major_id = OBJECT_ID(QUOTENAME(t.TABLE_SCHEMA) + '.' + QUOTENAME(t.TABLE_NAME))")
+	sql.WriteString(" and ep.minor_id = 0 and ep.name = 'MS_Description'")
+	sql.WriteString(" where t.TABLE_NAME = @p1")
+
+	var args = []interface{}{tableName}
+
+	if len(tableSchema) > 0 {
+		sql.WriteString(" and t.TABLE_SCHEMA = @p2")
+		args = append(args, tableSchema[0])
+	}
+
+	var info mssqlTableInfo
+
+	_, err := d.query(sql.String(), &info, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := d.LoadColumns(info.Name, info.Schema)
+
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error on query columns of the table %s", d.quoteString(info.Name)))
+	}
+
+	table := NewTable(info.Name, columns...)
+
+	table.schema = info.Schema
+
+	if info.Comment.Valid {
+		table.SetComment(info.Comment.String)
+	}
+
+	// load from databse
+	table.added = false
+
+	return table, nil
+}
+
+// Load table constraints from the database
+func (d mssql) LoadTableConstraints(tableName string, tableSchema ...string) (TableConstraints, error) {
+	var tableConstraints TableConstraints
+
+	var sqlStr = `
+SELECT
+    kc.name AS name,
+    col.name AS column_name,
+    CASE kc.type WHEN 'PK' THEN 'PRIMARY KEY' ELSE 'UNIQUE' END AS type,
+    NULL AS foreign_table_schema,
+    NULL AS foreign_table_name,
+    NULL AS foreign_column_name,
+    NULL AS on_update,
+    NULL AS on_delete,
+    NULL AS check_expr,
+    ic.key_ordinal AS position
+FROM sys.key_constraints AS kc
+INNER JOIN sys.index_columns AS ic
+    ON ic.object_id = kc.parent_object_id AND ic.index_id = kc.unique_index_id
+INNER JOIN sys.columns AS col
+    ON col.object_id = ic.object_id AND col.column_id = ic.column_id
+WHERE kc.parent_object_id = OBJECT_ID(@p1)
+UNION ALL
+SELECT
+    fk.name AS name,
+    col.name AS column_name,
+    'FOREIGN KEY' AS type,
+    OBJECT_SCHEMA_NAME(fk.referenced_object_id) AS foreign_table_schema,
+    OBJECT_NAME(fk.referenced_object_id) AS foreign_table_name,
+    fcol.name AS foreign_column_name,
+    REPLACE(fk.update_referential_action_desc, '_', ' ') AS on_update,
+    REPLACE(fk.delete_referential_action_desc, '_', ' ') AS on_delete,
+    NULL AS check_expr,
+    fkc.constraint_column_id AS position
+FROM sys.foreign_keys AS fk
+INNER JOIN sys.foreign_key_columns AS fkc
+    ON fkc.constraint_object_id = fk.object_id
+INNER JOIN sys.columns AS col
+    ON col.object_id = fkc.parent_object_id AND col.column_id = fkc.parent_column_id
+INNER JOIN sys.columns AS fcol
+    ON fcol.object_id = fkc.referenced_object_id AND fcol.column_id = fkc.referenced_column_id
+WHERE fk.parent_object_id = OBJECT_ID(@p1)
+UNION ALL
+SELECT
+    cc.name AS name,
+    COALESCE(col.name, '') AS column_name,
+    'CHECK' AS type,
+    NULL AS foreign_table_schema,
+    NULL AS foreign_table_name,
+    NULL AS foreign_column_name,
+    NULL AS on_update,
+    NULL AS on_delete,
+    cc.definition AS check_expr,
+    0 AS position
+FROM sys.check_constraints AS cc
+LEFT JOIN sys.columns AS col
+    ON col.object_id = cc.parent_object_id AND col.column_id = cc.parent_column_id
+WHERE cc.parent_object_id = OBJECT_ID(@p1)
+ORDER BY position ASC`
+
+	// OBJECT_ID takes the quoted name of the table
+	name := d.QuoteIdent(tableName)
+
+	if len(tableSchema) > 0 {
+		name = d.QuoteIdent(tableSchema[0]) + "." + name
+	}
+
+	var constraints TableConstraintInfos
+
+	_, err := d.query(sqlStr, &constraints, name)
+
+	if err != nil {
+		return tableConstraints, err
+	}
+
+	for typ, nameGroup := range constraints.Group() {
+		for name, items := range nameGroup {
+			switch typ {
+			case "PRIMARY KEY":
+				tableConstraints.SetPrimaryKey(constraint.PrimaryKey{
+					Name:        name,
+					ColumnNames: items.ColumnNames(),
+				})
+			case "FOREIGN KEY":
+				tableConstraints.AddForeignKeys(constraint.ForeignKey{
+					Name:               name,
+					ColumnNames:        items.ColumnNames(),
+					ForeignSchemaName:  items[0].ForeignTableSchema.String,
+					ForeignTableName:   items[0].ForeignTableName.String,
+					ForeignColumnNames: items.ForeignColumnNames(),
+					OnDelete:           items[0].OnDelete.String,
+					OnUpdate:           items[0].OnUpdate.String,
+				})
+			case "UNIQUE":
+				tableConstraints.AddUniques(constraint.Unique{
+					Name:        name,
+					ColumnNames: items.ColumnNames(),
+				})
+			case "CHECK":
+				tableConstraints.AddChecks(constraint.Check{
+					Name:        name,
+					ColumnNames: items.ColumnNames(),
+					Expression:  items[0].CheckExpr.String,
+				})
+			}
+		}
+	}
+
+	return tableConstraints, nil
+}
@@ -25,6 +25,13 @@ func SQLite3(query Query) Dialect {
 	}
 }
 
+// MSSQL schema dialect of SQL Server
+func MSSQL(query Query) Dialect {
+	return mssql{
+		query: query,
+	}
+}
+
 // query and load
 type Query func(sql string, dest interface{}, args ...interface{}) (int, error)
 
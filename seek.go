@@ -0,0 +1,287 @@
+package dbx
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Cursor holds the values of the ORDER BY columns of a row,
+// in the same order as the columns.
+// It marks where a page of keyset pagination starts or ends.
+type Cursor []interface{}
+
+// Encode returns an opaque token of the cursor that is safe in URLs.
+// A nil cursor is encoded as an empty string.
+func (c Cursor) Encode() (string, error) {
+	if c == nil {
+		return "", nil
+	}
+	b, err := json.Marshal([]interface{}(c))
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeCursor decodes a token returned by Cursor.Encode.
+// An empty token is decoded as a nil cursor.
+//
+// Values are decoded from JSON, so integers come back as int64,
+// other numbers as float64, and time.Time as string.
+func DecodeCursor(token string) (Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var c []interface{}
+	if err := dec.Decode(&c); err != nil || len(c) == 0 {
+		return nil, ErrInvalidCursor
+	}
+	for i, v := range c {
+		n, ok := v.(json.Number)
+		if !ok {
+			continue
+		}
+		if iv, err := n.Int64(); err == nil {
+			c[i] = iv
+		} else if fv, err := n.Float64(); err == nil {
+			c[i] = fv
+		}
+	}
+	return Cursor(c), nil
+}
+
+// Page is the result of LoadPage.
+type Page struct {
+	// Count is the number of rows loaded.
+	Count int
+	// Next is the cursor to pass to SeekAfter for the next page.
+	// It is nil on the last page.
+	Next Cursor
+	// Prev is the cursor to pass to SeekBefore for the previous page.
+	// It is nil on the first page.
+	Prev Cursor
+}
+
+// seek is the keyset condition of a SelectStmt.
+type seek struct {
+	cursor Cursor
+	before bool
+}
+
+// seekColumns returns the ORDER BY columns set by OrderAsc, OrderDesc and OrderDir.
+func seekColumns(order []Builder) ([]*orderBy, error) {
+	if len(order) == 0 {
+		return nil, ErrInvalidCursor
+	}
+	column := make([]*orderBy, len(order))
+	for i, o := range order {
+		o, ok := o.(*orderBy)
+		if !ok || o.expr != nil {
+			return nil, ErrInvalidCursor
+		}
+		column[i] = o
+	}
+	return column, nil
+}
+
+// cond builds the condition for rows after (or before) the cursor.
+//
+// If all columns have the same direction, it is a row value comparison like
+// `(a, b) > (1, 2)`. Otherwise it is expanded to
+// `a > 1 OR (a = 1 AND b < 2)`.
+func (s *seek) cond(order []Builder) (Builder, error) {
+	column, err := seekColumns(order)
+	if err != nil {
+		return nil, err
+	}
+	if len(column) != len(s.cursor) {
+		return nil, ErrInvalidCursor
+	}
+
+	cmp := func(o *orderBy) string {
+		// ASC goes forward with >, and before flips it
+		if (o.dir == desc) != s.before {
+			return "<"
+		}
+		return ">"
+	}
+
+	mixed := false
+	for _, o := range column[1:] {
+		if o.dir != column[0].dir {
+			mixed = true
+			break
+		}
+	}
+
+	if !mixed {
+		pred := cmp(column[0])
+		return BuildFunc(func(d Dialect, buf Buffer) error {
+			if len(column) == 1 {
+				return buildCmp(d, buf, pred, column[0].column, s.cursor[0])
+			}
+			buf.WriteString("(")
+			for i, o := range column {
+				if i > 0 {
+					buf.WriteString(", ")
+				}
+				buf.WriteString(d.QuoteIdent(o.column))
+			}
+			buf.WriteString(") ")
+			buf.WriteString(pred)
+			buf.WriteString(" ")
+			buf.WriteString(placeholder)
+			buf.WriteValue([]interface{}(s.cursor))
+			return nil
+		}), nil
+	}
+
+	var or []Builder
+	for i, o := range column {
+		var and []Builder
+		for j := 0; j < i; j++ {
+			and = append(and, Eq(column[j].column, s.cursor[j]))
+		}
+		pred, col, value := cmp(o), o.column, s.cursor[i]
+		and = append(and, BuildFunc(func(d Dialect, buf Buffer) error {
+			return buildCmp(d, buf, pred, col, value)
+		}))
+		or = append(or, And(and...))
+	}
+	return Or(or...), nil
+}
+
+// order returns the ORDER BY to query with.
+// Before a cursor, rows are queried in reverse order and reversed after loading.
+func (s *seek) order(order []Builder) []Builder {
+	if !s.before {
+		return order
+	}
+	reversed := make([]Builder, len(order))
+	for i, o := range order {
+		if o, ok := o.(*orderBy); ok {
+			reversed[i] = &orderBy{column: o.column, expr: o.expr, dir: !o.dir}
+		} else {
+			reversed[i] = o
+		}
+	}
+	return reversed
+}
+
+// SeekAfter selects the rows after cursor in keyset pagination.
+//
+// The ORDER BY columns must be set with OrderAsc, OrderDesc or OrderDir,
+// and should be unique together, like `created_at DESC, id DESC`.
+// cursor holds the values of these columns, usually Page.Next.
+// A nil cursor selects the first page.
+func (b *SelectStmt) SeekAfter(cursor Cursor) *SelectStmt {
+	b.seek = nil
+	if cursor != nil {
+		b.seek = &seek{cursor: cursor}
+	}
+	return b
+}
+
+// SeekBefore selects the rows before cursor in keyset pagination,
+// usually with Page.Prev. See SeekAfter.
+func (b *SelectStmt) SeekBefore(cursor Cursor) *SelectStmt {
+	b.seek = nil
+	if cursor != nil {
+		b.seek = &seek{cursor: cursor, before: true}
+	}
+	return b
+}
+
+// LoadPage loads a page of keyset pagination into a slice of structs,
+// and returns the cursors of the next and previous pages.
+//
+// One more row than Limit is queried to tell whether there is another page.
+func (b *SelectStmt) LoadPage(value interface{}) (*Page, error) {
+	return b.LoadPageContext(context.Background(), value)
+}
+
+func (b *SelectStmt) LoadPageContext(ctx context.Context, value interface{}) (*Page, error) {
+	column, err := seekColumns(b.Order)
+	if err != nil {
+		return nil, err
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return nil, ErrInvalidPointer
+	}
+	v = v.Elem()
+
+	stmt := *b
+	if b.LimitCount >= 0 {
+		stmt.LimitCount = b.LimitCount + 1
+	}
+	count, err := stmt.LoadContext(ctx, value)
+	if err != nil {
+		return nil, err
+	}
+
+	more := false
+	if b.LimitCount >= 0 && int64(count) > b.LimitCount {
+		more = true
+		count = int(b.LimitCount)
+		v.Set(v.Slice(0, count))
+	}
+
+	before := b.seek != nil && b.seek.before
+	if before {
+		swap := reflect.Swapper(v.Interface())
+		for i, j := 0, count-1; i < j; i, j = i+1, j-1 {
+			swap(i, j)
+		}
+	}
+
+	page := &Page{Count: count}
+	if count == 0 {
+		return page, nil
+	}
+
+	name := make([]string, len(column))
+	for i, o := range column {
+		// rows are loaded by column name without table
+		name[i] = o.column[strings.LastIndexByte(o.column, '.')+1:]
+	}
+	cursorOf := func(elem reflect.Value) (Cursor, error) {
+		found := make([]interface{}, len(name))
+		newTagStore().findValueByName(elem, name, found, false)
+		c := make(Cursor, len(found))
+		for i, f := range found {
+			if f == nil {
+				return nil, ErrInvalidCursor
+			}
+			c[i] = f.(reflect.Value).Interface()
+		}
+		return c, nil
+	}
+
+	// Before a cursor, there are more rows after the page.
+	// After a cursor, there are more rows before the page.
+	if more || before {
+		page.Next, err = cursorOf(v.Index(count - 1))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if (more && before) || (b.seek != nil && !before) {
+		page.Prev, err = cursorOf(v.Index(0))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
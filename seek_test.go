@@ -0,0 +1,124 @@
+package dbx
+
+import (
+	"testing"
+
+	"github.com/gokit/dbx/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeek(t *testing.T) {
+	for _, test := range []struct {
+		builder Builder
+		d       Dialect
+		want    string
+	}{
+		{
+			builder: Select("*").From("t").OrderAsc("id").SeekAfter(Cursor{10}).Limit(2),
+			d:       dialect.MySQL,
+			want:    "SELECT * FROM `t` WHERE `id` > 10 ORDER BY `id` ASC LIMIT 2",
+		},
+		{
+			builder: Select("*").From("t").Where(Eq("a", 1)).
+				OrderDesc("created_at").OrderDesc("id").
+				SeekAfter(Cursor{"2020-01-01", 10}),
+			d:    dialect.PostgreSQL,
+			want: `SELECT * FROM "t" WHERE (("created_at", "id") < ('2020-01-01',10)) AND ("a" = 1) ORDER BY "created_at" DESC, "id" DESC`,
+		},
+		{
+			builder: Select("*").From("t").
+				OrderDesc("score").OrderAsc("id").
+				SeekAfter(Cursor{5, 10}),
+			d:    dialect.SQLite3,
+			want: `SELECT * FROM "t" WHERE ("score" < 5) OR (("score" = 5) AND ("id" > 10)) ORDER BY "score" DESC, "id" ASC`,
+		},
+		{
+			builder: Select("*").From("t").
+				OrderDesc("score").OrderAsc("id").
+				SeekBefore(Cursor{5, 10}),
+			d:    dialect.SQLite3,
+			want: `SELECT * FROM "t" WHERE ("score" > 5) OR (("score" = 5) AND ("id" < 10)) ORDER BY "score" ASC, "id" DESC`,
+		},
+	} {
+		s, err := ToRawSql(test.d, test.builder)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+
+	_, err := ToRawSql(dialect.MySQL, Select("*").From("t").OrderAsc("id").SeekAfter(Cursor{1, 2}))
+	require.Equal(t, ErrInvalidCursor, err)
+
+	_, err = ToRawSql(dialect.MySQL, Select("*").From("t").OrderBy("id").SeekAfter(Cursor{1}))
+	require.Equal(t, ErrInvalidCursor, err)
+}
+
+func TestCursorEncode(t *testing.T) {
+	token, err := Cursor{"a", 1, 1.5}.Encode()
+	require.NoError(t, err)
+
+	c, err := DecodeCursor(token)
+	require.NoError(t, err)
+	require.Equal(t, Cursor{"a", int64(1), 1.5}, c)
+
+	token, err = Cursor(nil).Encode()
+	require.NoError(t, err)
+	require.Equal(t, "", token)
+
+	c, err = DecodeCursor("")
+	require.NoError(t, err)
+	require.Nil(t, c)
+
+	_, err = DecodeCursor("not a cursor")
+	require.Equal(t, ErrInvalidCursor, err)
+}
+
+func TestSQLite3LoadPage(t *testing.T) {
+	sess := sqlite3Session
+	reset(t, sess)
+
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		_, err := sess.InsertInto("dbx_people").Pair("name", name).Pair("email", name+"@test.com").Exec()
+		require.NoError(t, err)
+	}
+
+	names := func(people []dbxPerson) []string {
+		var s []string
+		for _, p := range people {
+			s = append(s, p.Name)
+		}
+		return s
+	}
+
+	var people []dbxPerson
+	page, err := sess.Select("*").From("dbx_people").OrderDesc("id").Limit(2).LoadPage(&people)
+	require.NoError(t, err)
+	require.Equal(t, []string{"e", "d"}, names(people))
+	require.Nil(t, page.Prev)
+	require.NotNil(t, page.Next)
+
+	token, err := page.Next.Encode()
+	require.NoError(t, err)
+	cursor, err := DecodeCursor(token)
+	require.NoError(t, err)
+
+	people = nil
+	page, err = sess.Select("*").From("dbx_people").OrderDesc("id").Limit(2).SeekAfter(cursor).LoadPage(&people)
+	require.NoError(t, err)
+	require.Equal(t, []string{"c", "b"}, names(people))
+	require.NotNil(t, page.Prev)
+	require.NotNil(t, page.Next)
+
+	next := page.Next
+	people = nil
+	page, err = sess.Select("*").From("dbx_people").OrderDesc("id").Limit(2).SeekAfter(next).LoadPage(&people)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, names(people))
+	require.Nil(t, page.Next)
+
+	people = nil
+	page, err = sess.Select("*").From("dbx_people").OrderDesc("id").Limit(2).SeekBefore(page.Prev).LoadPage(&people)
+	require.NoError(t, err)
+	require.Equal(t, []string{"c", "b"}, names(people))
+	require.NotNil(t, page.Prev)
+	require.NotNil(t, page.Next)
+}
@@ -29,7 +29,12 @@ type SelectStmt struct {
 	LimitCount  int64
 	OffsetCount int64
 
-	comments Comments
+	seek       *seek
+	lock       *rowLock
+	strictLock bool
+	with       withClause
+	windows    []namedWindow
+	comments   Comments
 }
 
 type SelectBuilder = SelectStmt
@@ -48,20 +53,33 @@ func (b *SelectStmt) Build(d Dialect, buf Buffer) error {
 		return err
 	}
 
+	err = b.with.Build(d, buf)
+	if err != nil {
+		return err
+	}
+
 	buf.WriteString("SELECT ")
 
 	if b.IsDistinct {
 		buf.WriteString("DISTINCT ")
 	}
 
+	// SQL Server has TOP for a limit without offset, and OFFSET FETCH otherwise
+	isMSSQL := d.DriverName() == "mssql"
+	if isMSSQL && b.LimitCount >= 0 && b.OffsetCount < 0 {
+		buildTop(buf, b.LimitCount)
+	}
+
 	for i, col := range b.Column {
 		if i > 0 {
 			buf.WriteString(", ")
 		}
 		switch col := col.(type) {
 		case string:
-			// FIXME: no quote ident
-			buf.WriteString(col)
+			err := buildIdentRef(d, buf, col, false)
+			if err != nil {
+				return err
+			}
 		default:
 			buf.WriteString(placeholder)
 			buf.WriteValue(col)
@@ -72,8 +90,10 @@ func (b *SelectStmt) Build(d Dialect, buf Buffer) error {
 		buf.WriteString(" FROM ")
 		switch table := b.Table.(type) {
 		case string:
-			// FIXME: no quote ident
-			buf.WriteString(table)
+			err := buildIdentRef(d, buf, table, true)
+			if err != nil {
+				return err
+			}
 		default:
 			buf.WriteString(placeholder)
 			buf.WriteValue(table)
@@ -88,9 +108,19 @@ func (b *SelectStmt) Build(d Dialect, buf Buffer) error {
 		}
 	}
 
-	if len(b.WhereCond) > 0 {
+	whereCond, orderCond := b.WhereCond, b.Order
+	if b.seek != nil {
+		cond, err := b.seek.cond(b.Order)
+		if err != nil {
+			return err
+		}
+		whereCond = mergeCond(cond, whereCond)
+		orderCond = b.seek.order(b.Order)
+	}
+
+	if len(whereCond) > 0 {
 		buf.WriteString(" WHERE ")
-		err := logicCond(b.WhereCond...).Build(d, buf)
+		err := logicCond(whereCond...).Build(d, buf)
 		if err != nil {
 			return err
 		}
@@ -117,9 +147,14 @@ func (b *SelectStmt) Build(d Dialect, buf Buffer) error {
 		}
 	}
 
-	if len(b.Order) > 0 {
+	err = b.buildWindows(d, buf)
+	if err != nil {
+		return err
+	}
+
+	if len(orderCond) > 0 {
 		buf.WriteString(" ORDER BY ")
-		for i, order := range b.Order {
+		for i, order := range orderCond {
 			if i > 0 {
 				buf.WriteString(", ")
 			}
@@ -130,14 +165,27 @@ func (b *SelectStmt) Build(d Dialect, buf Buffer) error {
 		}
 	}
 
-	if b.LimitCount >= 0 {
-		buf.WriteString(" LIMIT ")
-		buf.WriteString(strconv.FormatInt(b.LimitCount, 10))
+	if isMSSQL {
+		if b.OffsetCount >= 0 {
+			buildOffsetFetch(buf, b.LimitCount, b.OffsetCount, len(orderCond) > 0)
+		}
+	} else {
+		if b.LimitCount >= 0 {
+			buf.WriteString(" LIMIT ")
+			buf.WriteString(strconv.FormatInt(b.LimitCount, 10))
+		}
+
+		if b.OffsetCount >= 0 {
+			buf.WriteString(" OFFSET ")
+			buf.WriteString(strconv.FormatInt(b.OffsetCount, 10))
+		}
 	}
 
-	if b.OffsetCount >= 0 {
-		buf.WriteString(" OFFSET ")
-		buf.WriteString(strconv.FormatInt(b.OffsetCount, 10))
+	if b.lock != nil {
+		err := b.lock.build(d, buf, b.strictLock)
+		if err != nil {
+			return err
+		}
 	}
 
 	if len(b.Suffixes) > 0 {
@@ -154,6 +202,12 @@ func (b *SelectStmt) Build(d Dialect, buf Buffer) error {
 }
 
 // Select creates a SelectStmt.
+// column can be Builder, or string.
+//
+// A string like `col`, `table.col`, `table.*` or `col AS alias` is quoted
+// as an identifier. Any other string, like `count(*)`, is written as raw SQL
+// for compatibility, so a column from user input must be checked, or be
+// passed as I. Use Expr to make raw SQL explicit.
 func Select(column ...interface{}) *SelectStmt {
 	return &SelectStmt{
 		Column:      column,
@@ -171,6 +225,7 @@ func prepareSelect(a []string) []interface{} {
 }
 
 // Select creates a SelectStmt.
+// Strings that are not identifiers are written as raw SQL like in Select.
 func (sess *Session) Select(column ...string) *SelectStmt {
 	b := Select(prepareSelect(column)...)
 	b.runner = sess
@@ -180,6 +235,7 @@ func (sess *Session) Select(column ...string) *SelectStmt {
 }
 
 // Select creates a SelectStmt.
+// Strings that are not identifiers are written as raw SQL like in Select.
 func (tx *Tx) Select(column ...string) *SelectStmt {
 	b := Select(prepareSelect(column)...)
 	b.runner = tx
@@ -220,6 +276,9 @@ func (tx *Tx) SelectBySql(query string, value ...interface{}) *SelectStmt {
 
 // From specifies table to select from.
 // table can be Builder like SelectStmt, or string.
+// A string like `table`, `schema.table` or `table alias` is quoted as an identifier.
+// Any other string is written as raw SQL for compatibility, so a table
+// from user input must be checked, or be passed as I.
 func (b *SelectStmt) From(table interface{}) *SelectStmt {
 	b.Table = table
 	return b
@@ -274,17 +333,22 @@ func (b *SelectStmt) GroupBy(col ...string) *SelectStmt {
 	return b
 }
 
+// OrderAsc sorts by col in ascending order.
+// col is always quoted as an identifier, so it is safe to take from user input.
 func (b *SelectStmt) OrderAsc(col string) *SelectStmt {
 	b.Order = append(b.Order, order(col, asc))
 	return b
 }
 
+// OrderDesc sorts by col in descending order.
+// col is always quoted as an identifier, so it is safe to take from user input.
 func (b *SelectStmt) OrderDesc(col string) *SelectStmt {
 	b.Order = append(b.Order, order(col, desc))
 	return b
 }
 
 // OrderBy reset and then specifies columns for ordering.
+// col is raw SQL like `id DESC`.
 func (b *SelectStmt) OrderBy(col string) *SelectStmt {
 
 	// reset
@@ -304,13 +368,15 @@ func (b *SelectStmt) Offset(n uint64) *SelectStmt {
 	return b
 }
 
-// Suffix adds an expression to the end of the query. This is useful to add dialect-specific clauses like FOR UPDATE
+// Suffix adds an expression to the end of the query. This is useful to add dialect-specific clauses.
+// Use ForUpdate or ForShare for row locks.
 func (b *SelectStmt) Suffix(suffix string, value ...interface{}) *SelectStmt {
 	b.Suffixes = append(b.Suffixes, Expr(suffix, value...))
 	return b
 }
 
 // Paginate fetches a page in a naive way for a small set of data.
+// Use SeekAfter and LoadPage for a large set of data.
 func (b *SelectStmt) Paginate(page, perPage uint64) *SelectStmt {
 	b.Limit(perPage)
 	b.Offset((page - 1) * perPage)
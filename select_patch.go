@@ -6,6 +6,13 @@ func (s *SelectStmt) AddSelect(column ...string) *SelectStmt {
 	return s
 }
 
+// AddColumn specifies columns for select.
+// column can be Builder like Case, or string like in Select.
+func (s *SelectStmt) AddColumn(column ...interface{}) *SelectStmt {
+	s.Column = append(s.Column, column...)
+	return s
+}
+
 // AndWhere adds a where condition.
 // query can be Builder or string. value is used only if query type is string.
 func (b *SelectStmt) AndWhere(query interface{}, value ...interface{}) *SelectStmt {
@@ -78,6 +85,32 @@ func (b *SelectStmt) AddOrderBy(cols ...string) *SelectStmt {
 	return b
 }
 
+// With adds a common table expression named name.
+// builder can be SelectStmt, or any other Builder.
+func (b *SelectStmt) With(name string, builder Builder) *SelectStmt {
+	b.with = b.with.add(name, nil, builder, false)
+	return b
+}
+
+// WithRecursive adds a recursive common table expression named name.
+// column lists the column names of the table and can be empty.
+func (b *SelectStmt) WithRecursive(name string, column []string, builder Builder) *SelectStmt {
+	b.with = b.with.add(name, column, builder, true)
+	return b
+}
+
+// OrderAscExpr sorts by an expression like Case in ascending order.
+func (b *SelectStmt) OrderAscExpr(expr Builder) *SelectStmt {
+	b.Order = append(b.Order, orderExpr(expr, asc))
+	return b
+}
+
+// OrderDescExpr sorts by an expression like Case in descending order.
+func (b *SelectStmt) OrderDescExpr(expr Builder) *SelectStmt {
+	b.Order = append(b.Order, orderExpr(expr, desc))
+	return b
+}
+
 // ToSql return the sql and args
 func (b *SelectStmt) ToSql() (string, []interface{}, error) {
 	return ToSql(b.Dialect, b)
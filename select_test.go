@@ -26,7 +26,7 @@ func TestSelectStmt(t *testing.T) {
 
 	err := builder.Build(dialect.MySQL, buf)
 	require.NoError(t, err)
-	require.Equal(t, "/* SELECT TEST */\nSELECT DISTINCT a, b FROM ? LEFT JOIN `table2` ON table.a1 = table.a2 WHERE (`c` = ?) GROUP BY d HAVING (`e` = ?) ORDER BY f ASC LIMIT 3 OFFSET 4 FOR UPDATE", buf.String())
+	require.Equal(t, "/* SELECT TEST */\nSELECT DISTINCT `a`, `b` FROM ? LEFT JOIN `table2` ON table.a1 = table.a2 WHERE (`c` = ?) GROUP BY d HAVING (`e` = ?) ORDER BY `f` ASC LIMIT 3 OFFSET 4 FOR UPDATE", buf.String())
 	// two functions cannot be compared
 	require.Equal(t, 3, len(buf.Value()))
 }
@@ -199,3 +199,101 @@ func TestPostgresArray(t *testing.T) {
 
 	require.Equal(t, []int64{1, 2, 3}, ns)
 }
+
+func TestSelectWith(t *testing.T) {
+	for _, test := range []struct {
+		builder Builder
+		d       Dialect
+		want    string
+	}{
+		{
+			builder: Select("*").
+				With("recent", Select("id").From("orders").Where(Gt("total", 10))).
+				From("recent"),
+			d:    dialect.MySQL,
+			want: "WITH `recent` AS (SELECT `id` FROM `orders` WHERE `total` > 10) SELECT * FROM `recent`",
+		},
+		{
+			builder: Select("n").
+				WithRecursive("t", []string{"n"}, UnionAll(
+					Select(Expr("1")),
+					Select(Expr("n + 1")).From("t").Where(Lt("n", 5)),
+				)).
+				From("t"),
+			d:    dialect.PostgreSQL,
+			want: `WITH RECURSIVE "t" ("n") AS (SELECT 1 UNION ALL SELECT n + 1 FROM "t" WHERE "n" < 5) SELECT "n" FROM "t"`,
+		},
+		{
+			builder: Select("*").
+				With("a", Select("x").From("t1")).
+				With("b", Expr("SELECT ?", 1)).
+				From("a"),
+			d:    dialect.SQLite3,
+			want: `WITH "a" AS (SELECT "x" FROM "t1"), "b" AS (SELECT 1) SELECT * FROM "a"`,
+		},
+	} {
+		s, err := ToRawSql(test.d, test.builder)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+}
+
+func TestSelectWithPlaceholder(t *testing.T) {
+	i := interpolator{
+		Buffer:       NewBuffer(),
+		Dialect:      dialect.PostgreSQL,
+		IgnoreBinary: true,
+	}
+	builder := Select("*").
+		With("a", Select("x").From("t1").Where("b = ?", []byte{1})).
+		From("a").
+		Where("c = ?", []byte{2})
+	err := i.encodePlaceholder(builder, true)
+	require.NoError(t, err)
+	require.Equal(t, `WITH "a" AS (SELECT "x" FROM "t1" WHERE b = $1) SELECT * FROM "a" WHERE c = $2`, i.String())
+	require.Equal(t, []interface{}{[]byte{1}, []byte{2}}, i.Value())
+}
+
+func TestSelectQuoteIdent(t *testing.T) {
+	for _, test := range []struct {
+		builder Builder
+		d       Dialect
+		want    string
+	}{
+		{
+			builder: Select("order", "user.name AS user_name", "t.*").From("user u").OrderAsc("order"),
+			d:       dialect.MySQL,
+			want:    "SELECT `order`, `user`.`name` AS `user_name`, `t`.* FROM `user` AS `u` ORDER BY `order` ASC",
+		},
+		{
+			builder: Select("*", "count(*)", Expr("MAX(id) AS m")).From("public.user").OrderDesc("u.id"),
+			d:       dialect.PostgreSQL,
+			want:    `SELECT *, count(*), MAX(id) AS m FROM "public"."user" ORDER BY "u"."id" DESC`,
+		},
+		{
+			builder: Select("id").From("t").OrderAsc("id; DROP TABLE t"),
+			d:       dialect.SQLite3,
+			want:    `SELECT "id" FROM "t" ORDER BY "id; DROP TABLE t" ASC`,
+		},
+		{
+			builder: Select("id").From("t").OrderDesc(`id" DESC, "x`),
+			d:       dialect.PostgreSQL,
+			want:    `SELECT "id" FROM "t" ORDER BY "id"" DESC, ""x" DESC`,
+		},
+		{
+			// strings other than identifiers are raw SQL in select columns and FROM
+			builder: Select("count(*)", "a + 1", "id; DROP TABLE t").From("t AS x, u"),
+			d:       dialect.PostgreSQL,
+			want:    `SELECT count(*), a + 1, id; DROP TABLE t FROM t AS x, u`,
+		},
+		{
+			builder: Select(I("id; DROP TABLE t")).From(I("t AS x")),
+			d:       dialect.PostgreSQL,
+			want:    `SELECT "id; DROP TABLE t" FROM "t AS x"`,
+		},
+	} {
+		s, err := ToRawSql(test.d, test.builder)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+}
@@ -19,7 +19,7 @@ func TestSQLMock(t *testing.T) {
 	}
 	sess := conn.NewSession(nil)
 
-	mock.ExpectQuery("SELECT id FROM suggestions").
+	mock.ExpectQuery("SELECT `id` FROM `suggestions`").
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
 	id, err := sess.Select("id").From("suggestions").ReturnInt64s()
 	require.NoError(t, err)
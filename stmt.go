@@ -0,0 +1,174 @@
+package dbx
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// DefaultStmtCacheSize is the number of prepared statements cached
+// by a Connection or a Tx if UsePrepared is given no size.
+var DefaultStmtCacheSize = 64
+
+type cachedStmt struct {
+	query   string
+	stmt    *sql.Stmt
+	users   int
+	removed bool
+}
+
+// stmtCache is an LRU cache of prepared statements keyed by SQL.
+//
+// A statement is closed when it is evicted or fails, but not before
+// every caller using it is done.
+type stmtCache struct {
+	mu      sync.Mutex
+	size    int
+	prepare func(ctx context.Context, query string) (*sql.Stmt, error)
+	lru     *list.List
+	m       map[string]*list.Element
+}
+
+func newStmtCache(size int, prepare func(ctx context.Context, query string) (*sql.Stmt, error)) *stmtCache {
+	if size <= 0 {
+		size = DefaultStmtCacheSize
+	}
+	return &stmtCache{
+		size:    size,
+		prepare: prepare,
+		lru:     list.New(),
+		m:       make(map[string]*list.Element),
+	}
+}
+
+// get returns the statement for query, preparing it if it is not cached.
+// The statement must be released with the error of its execution.
+func (c *stmtCache) get(ctx context.Context, query string) (*cachedStmt, error) {
+	c.mu.Lock()
+	if e, ok := c.m[query]; ok {
+		c.lru.MoveToFront(e)
+		s := e.Value.(*cachedStmt)
+		s.users++
+		c.mu.Unlock()
+		return s, nil
+	}
+	c.mu.Unlock()
+
+	stmt, err := c.prepare(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.m[query]; ok {
+		// prepared by another caller in the meantime
+		stmt.Close()
+		c.lru.MoveToFront(e)
+		s := e.Value.(*cachedStmt)
+		s.users++
+		return s, nil
+	}
+	s := &cachedStmt{query: query, stmt: stmt, users: 1}
+	c.m[query] = c.lru.PushFront(s)
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+	return s, nil
+}
+
+// release gives back s, and removes it from the cache if err is not nil.
+func (c *stmtCache) release(s *cachedStmt, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s.users--
+	if err != nil && !s.removed {
+		c.remove(c.m[s.query])
+	}
+	if s.removed && s.users == 0 {
+		s.stmt.Close()
+	}
+}
+
+func (c *stmtCache) remove(e *list.Element) {
+	s := c.lru.Remove(e).(*cachedStmt)
+	delete(c.m, s.query)
+	s.removed = true
+	if s.users == 0 {
+		s.stmt.Close()
+	}
+}
+
+func (c *stmtCache) exec(ctx context.Context, query string, value []interface{}) (sql.Result, error) {
+	s, err := c.get(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.stmt.ExecContext(ctx, value...)
+	c.release(s, err)
+	return result, err
+}
+
+func (c *stmtCache) query(ctx context.Context, query string, value []interface{}) (*sql.Rows, error) {
+	s, err := c.get(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	// closing the statement is deferred by database/sql until rows are closed
+	rows, err := s.stmt.QueryContext(ctx, value...)
+	c.release(s, err)
+	return rows, err
+}
+
+// stmtRunner is a runner with prepared statements.
+type stmtRunner interface {
+	stmtCache() *stmtCache
+}
+
+// stmtCacheOf returns the statement cache of runner,
+// or nil if values should be interpolated.
+func stmtCacheOf(r runner) *stmtCache {
+	if r, ok := r.(stmtRunner); ok {
+		return r.stmtCache()
+	}
+	return nil
+}
+
+// UsePrepared makes sessions created later send every value as a bind
+// parameter of a prepared statement, instead of interpolating values into SQL.
+// Up to cacheSize statements are cached by SQL for the Connection and for each Tx.
+func (conn *Connection) UsePrepared(cacheSize int) *Connection {
+	conn.stmtMu.Lock()
+	defer conn.stmtMu.Unlock()
+	conn.prepared = true
+	conn.stmtCacheSize = cacheSize
+	return conn
+}
+
+func (conn *Connection) stmtCache() *stmtCache {
+	conn.stmtMu.Lock()
+	defer conn.stmtMu.Unlock()
+	if conn.stmts == nil {
+		conn.stmts = newStmtCache(conn.stmtCacheSize, conn.DB.PrepareContext)
+	}
+	return conn.stmts
+}
+
+// UsePrepared turns on or off sending values as bind parameters of prepared
+// statements for sess and the transactions it begins later.
+func (sess *Session) UsePrepared(on bool) *Session {
+	sess.prepared = on
+	return sess
+}
+
+func (sess *Session) stmtCache() *stmtCache {
+	if !sess.prepared {
+		return nil
+	}
+	return sess.Connection.stmtCache()
+}
+
+func (tx *Tx) stmtCache() *stmtCache {
+	return tx.stmts
+}
@@ -0,0 +1,75 @@
+package dbx
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/gokit/dbx/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreparedStmtCache(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	conn := &Connection{
+		DB:            db,
+		EventReceiver: &NullEventReceiver{},
+		Dialect:       dialect.PostgreSQL,
+	}
+	sess := conn.UsePrepared(1).NewSession(nil)
+
+	selectSQL := regexp.QuoteMeta(`SELECT "id" FROM "users" WHERE ("name" = $1) AND ("tag" IN ($2,$3))`)
+	updateSQL := regexp.QuoteMeta(`UPDATE "users" SET "name" = $1 WHERE "id" = $2`)
+
+	// prepared once, and used twice
+	mock.ExpectPrepare(selectSQL).WillBeClosed()
+	mock.ExpectQuery(selectSQL).WithArgs("a", "x", "y").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(selectSQL).WithArgs("b", "x", "y").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	for _, name := range []string{"a", "b"} {
+		var id int64
+		err = sess.Select("id").From("users").Where(And(Eq("name", name), Eq("tag", []string{"x", "y"}))).LoadOne(&id)
+		require.NoError(t, err)
+	}
+
+	// the least recently used statement is evicted
+	mock.ExpectPrepare(updateSQL).WillBeClosed()
+	mock.ExpectExec(updateSQL).WithArgs("c", 1).WillReturnError(errors.New("conn reset"))
+	_, err = sess.Update("users").Set("name", "c").Where(Eq("id", 1)).Exec()
+	require.Error(t, err)
+
+	// the failed statement is prepared again
+	mock.ExpectPrepare(updateSQL)
+	mock.ExpectExec(updateSQL).WithArgs("c", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err = sess.Update("users").Set("name", "c").Where(Eq("id", 1)).Exec()
+	require.NoError(t, err)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	// a session can turn it off
+	raw, _, err := sess.UsePrepared(false).Update("users").Set("name", "c").Where(Eq("id", 1)).ToSql()
+	require.NoError(t, err)
+	require.Equal(t, `UPDATE "users" SET "name" = ? WHERE "id" = ?`, raw)
+}
+
+func TestSQLite3Prepared(t *testing.T) {
+	sess := sqlite3Session.Connection.NewSession(nil).UsePrepared(true)
+	reset(t, sess)
+
+	tx, err := sess.Begin()
+	require.NoError(t, err)
+	defer tx.RollbackUnlessCommitted()
+	for _, name := range []string{"a", "b", "it's"} {
+		_, err := tx.InsertInto("dbx_people").Pair("name", name).Pair("email", []byte("x")).Exec()
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, tx.stmts.lru.Len())
+	require.NoError(t, tx.Commit())
+
+	var names []string
+	_, err = sess.Select("name").From("dbx_people").Where(Eq("name", []string{"a", "it's"})).OrderAsc("id").Load(&names)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "it's"}, names)
+}
@@ -3,6 +3,7 @@ package dbx
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"time"
 )
 
@@ -12,6 +13,10 @@ type Tx struct {
 	Dialect
 	*sql.Tx
 	Timeout time.Duration
+
+	stmts            *stmtCache
+	maxAllowedPacket int
+	driver           driver.Driver
 }
 
 // GetTimeout returns timeout enforced in Tx.
@@ -27,11 +32,22 @@ func (sess *Session) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, err
 	}
 	sess.Event("dbx.begin")
 
+	var stmts *stmtCache
+	if sess.prepared {
+		sess.stmtMu.Lock()
+		size := sess.stmtCacheSize
+		sess.stmtMu.Unlock()
+		stmts = newStmtCache(size, tx.PrepareContext)
+	}
+
 	return &Tx{
-		EventReceiver: sess.EventReceiver,
-		Dialect:       sess.Dialect,
-		Tx:            tx,
-		Timeout:       sess.GetTimeout(),
+		EventReceiver:    sess.EventReceiver,
+		Dialect:          sess.Dialect,
+		Tx:               tx,
+		Timeout:          sess.GetTimeout(),
+		stmts:            stmts,
+		maxAllowedPacket: sess.maxAllowedPacket,
+		driver:           sess.Driver(),
 	}, nil
 }
 
@@ -1,47 +1,317 @@
 package dbx
 
-type union struct {
-	builder []Builder
+import (
+	"context"
+	"database/sql"
+	"strconv"
+)
+
+type setOp uint8
+
+const (
+	opUnion setOp = iota
+	opIntersect
+	opExcept
+)
+
+// UnionStmt builds `... UNION ...`, `... INTERSECT ...` and `... EXCEPT ...`.
+type UnionStmt struct {
+	runner
+	EventReceiver
+	Dialect
+
+	op      setOp
 	all     bool
+	builder []Builder
+
+	Order       []Builder
+	LimitCount  int64
+	OffsetCount int64
 }
 
-// Union builds `... UNION ...`.
-func Union(builder ...Builder) interface {
-	Builder
-	As(string) Builder
-} {
-	return &union{
-		builder: builder,
+type UnionBuilder = UnionStmt
+
+func newUnion(op setOp, all bool, builder []Builder) *UnionStmt {
+	return &UnionStmt{
+		op:          op,
+		all:         all,
+		builder:     builder,
+		LimitCount:  -1,
+		OffsetCount: -1,
 	}
 }
 
+// Union builds `... UNION ...`.
+func Union(builder ...Builder) *UnionStmt {
+	return newUnion(opUnion, false, builder)
+}
+
 // UnionAll builds `... UNION ALL ...`.
-func UnionAll(builder ...Builder) interface {
-	Builder
-	As(string) Builder
-} {
-	return &union{
-		builder: builder,
-		all:     true,
+func UnionAll(builder ...Builder) *UnionStmt {
+	return newUnion(opUnion, true, builder)
+}
+
+// Intersect builds `... INTERSECT ...`.
+func Intersect(builder ...Builder) *UnionStmt {
+	return newUnion(opIntersect, false, builder)
+}
+
+// IntersectAll builds `... INTERSECT ALL ...`.
+// It is not supported in SQLite3.
+func IntersectAll(builder ...Builder) *UnionStmt {
+	return newUnion(opIntersect, true, builder)
+}
+
+// Except builds `... EXCEPT ...`.
+func Except(builder ...Builder) *UnionStmt {
+	return newUnion(opExcept, false, builder)
+}
+
+// ExceptAll builds `... EXCEPT ALL ...`.
+// It is not supported in SQLite3.
+func ExceptAll(builder ...Builder) *UnionStmt {
+	return newUnion(opExcept, true, builder)
+}
+
+func (sess *Session) bindUnion(b *UnionStmt) *UnionStmt {
+	b.runner = sess
+	b.EventReceiver = sess.EventReceiver
+	b.Dialect = sess.Dialect
+	return b
+}
+
+func (tx *Tx) bindUnion(b *UnionStmt) *UnionStmt {
+	b.runner = tx
+	b.EventReceiver = tx.EventReceiver
+	b.Dialect = tx.Dialect
+	return b
+}
+
+// Union creates a UnionStmt.
+func (sess *Session) Union(builder ...Builder) *UnionStmt {
+	return sess.bindUnion(Union(builder...))
+}
+
+// UnionAll creates a UnionStmt.
+func (sess *Session) UnionAll(builder ...Builder) *UnionStmt {
+	return sess.bindUnion(UnionAll(builder...))
+}
+
+// Intersect creates a UnionStmt.
+func (sess *Session) Intersect(builder ...Builder) *UnionStmt {
+	return sess.bindUnion(Intersect(builder...))
+}
+
+// IntersectAll creates a UnionStmt.
+func (sess *Session) IntersectAll(builder ...Builder) *UnionStmt {
+	return sess.bindUnion(IntersectAll(builder...))
+}
+
+// Except creates a UnionStmt.
+func (sess *Session) Except(builder ...Builder) *UnionStmt {
+	return sess.bindUnion(Except(builder...))
+}
+
+// ExceptAll creates a UnionStmt.
+func (sess *Session) ExceptAll(builder ...Builder) *UnionStmt {
+	return sess.bindUnion(ExceptAll(builder...))
+}
+
+// Union creates a UnionStmt.
+func (tx *Tx) Union(builder ...Builder) *UnionStmt {
+	return tx.bindUnion(Union(builder...))
+}
+
+// UnionAll creates a UnionStmt.
+func (tx *Tx) UnionAll(builder ...Builder) *UnionStmt {
+	return tx.bindUnion(UnionAll(builder...))
+}
+
+// Intersect creates a UnionStmt.
+func (tx *Tx) Intersect(builder ...Builder) *UnionStmt {
+	return tx.bindUnion(Intersect(builder...))
+}
+
+// IntersectAll creates a UnionStmt.
+func (tx *Tx) IntersectAll(builder ...Builder) *UnionStmt {
+	return tx.bindUnion(IntersectAll(builder...))
+}
+
+// Except creates a UnionStmt.
+func (tx *Tx) Except(builder ...Builder) *UnionStmt {
+	return tx.bindUnion(Except(builder...))
+}
+
+// ExceptAll creates a UnionStmt.
+func (tx *Tx) ExceptAll(builder ...Builder) *UnionStmt {
+	return tx.bindUnion(ExceptAll(builder...))
+}
+
+// isCompound tells whether an operand must be enclosed,
+// because it has its own ORDER BY, LIMIT or set operation.
+func isCompound(b Builder) bool {
+	switch b := b.(type) {
+	case *SelectStmt:
+		return b.raw.Query == "" && (len(b.Order) > 0 || b.LimitCount >= 0 || b.OffsetCount >= 0)
+	case *UnionStmt:
+		return true
 	}
+	return false
 }
 
-func (u *union) Build(d Dialect, buf Buffer) error {
+func (u *UnionStmt) Build(d Dialect, buf Buffer) error {
+	isSQLite := d.DriverName() == "sqlite"
+	if u.all && u.op != opUnion && isSQLite {
+		return ErrNotSupported
+	}
+
 	for i, b := range u.builder {
 		if i > 0 {
-			buf.WriteString(" UNION ")
+			switch u.op {
+			case opIntersect:
+				buf.WriteString(" INTERSECT ")
+			case opExcept:
+				buf.WriteString(" EXCEPT ")
+			default:
+				buf.WriteString(" UNION ")
+			}
 			if u.all {
 				buf.WriteString("ALL ")
 			}
 		}
+
+		// sqlite3 does not allow parentheses around operands,
+		// but an operand can still be selected from as a subquery.
+		enclose := isCompound(b)
+		if enclose {
+			if isSQLite {
+				buf.WriteString("SELECT * FROM (")
+			} else {
+				buf.WriteString("(")
+			}
+		}
 		err := b.Build(d, buf)
 		if err != nil {
 			return err
 		}
+		if enclose {
+			buf.WriteString(")")
+		}
+	}
+
+	if len(u.Order) > 0 {
+		buf.WriteString(" ORDER BY ")
+		for i, order := range u.Order {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			err := order.Build(d, buf)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if d.DriverName() == "mssql" {
+		if u.LimitCount >= 0 || u.OffsetCount >= 0 {
+			buildOffsetFetch(buf, u.LimitCount, u.OffsetCount, len(u.Order) > 0)
+		}
+		return nil
+	}
+
+	if u.LimitCount >= 0 {
+		buf.WriteString(" LIMIT ")
+		buf.WriteString(strconv.FormatInt(u.LimitCount, 10))
+	}
+
+	if u.OffsetCount >= 0 {
+		buf.WriteString(" OFFSET ")
+		buf.WriteString(strconv.FormatInt(u.OffsetCount, 10))
 	}
 	return nil
 }
 
-func (u *union) As(alias string) Builder {
+// As creates alias for the set operation.
+func (u *UnionStmt) As(alias string) Builder {
 	return as(u, alias)
 }
+
+// OrderAsc sorts the combined result by col in ascending order.
+func (u *UnionStmt) OrderAsc(col string) *UnionStmt {
+	u.Order = append(u.Order, order(col, asc))
+	return u
+}
+
+// OrderDesc sorts the combined result by col in descending order.
+func (u *UnionStmt) OrderDesc(col string) *UnionStmt {
+	u.Order = append(u.Order, order(col, desc))
+	return u
+}
+
+// OrderBy reset and then specifies columns for ordering the combined result.
+func (u *UnionStmt) OrderBy(col string) *UnionStmt {
+
+	// reset
+	u.Order = nil
+
+	u.Order = append(u.Order, Expr(col))
+	return u
+}
+
+// Limit limits the number of rows of the combined result.
+func (u *UnionStmt) Limit(n uint64) *UnionStmt {
+	u.LimitCount = int64(n)
+	return u
+}
+
+// Offset skips rows of the combined result.
+func (u *UnionStmt) Offset(n uint64) *UnionStmt {
+	u.OffsetCount = int64(n)
+	return u
+}
+
+// ToSql return the sql and args
+func (u *UnionStmt) ToSql() (string, []interface{}, error) {
+	return ToSql(u.Dialect, u)
+}
+
+// ToRawSql return the raw sql
+func (u *UnionStmt) ToRawSql() (string, error) {
+	return ToRawSql(u.Dialect, u)
+}
+
+// Rows executes the query and returns the rows returned, or any error encountered.
+func (u *UnionStmt) Rows() (*sql.Rows, error) {
+	return u.RowsContext(context.Background())
+}
+
+func (u *UnionStmt) RowsContext(ctx context.Context) (*sql.Rows, error) {
+	_, rows, err := queryRows(ctx, u.runner, u.EventReceiver, u, u.Dialect)
+	return rows, err
+}
+
+func (u *UnionStmt) LoadOneContext(ctx context.Context, value interface{}) error {
+	count, err := query(ctx, u.runner, u.EventReceiver, u, u.Dialect, value)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// LoadOne loads SQL result into go variable that is not a slice.
+// Unlike Load, it returns ErrNotFound if the SQL result row count is 0.
+func (u *UnionStmt) LoadOne(value interface{}) error {
+	return u.LoadOneContext(context.Background(), value)
+}
+
+func (u *UnionStmt) LoadContext(ctx context.Context, value interface{}) (int, error) {
+	return query(ctx, u.runner, u.EventReceiver, u, u.Dialect, value)
+}
+
+// Load loads multi-row SQL result into a slice of go variables.
+func (u *UnionStmt) Load(value interface{}) (int, error) {
+	return u.LoadContext(context.Background(), value)
+}
@@ -0,0 +1,84 @@
+package dbx
+
+import (
+	"testing"
+
+	"github.com/gokit/dbx/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnionStmt(t *testing.T) {
+	for _, test := range []struct {
+		builder Builder
+		d       Dialect
+		want    string
+	}{
+		{
+			builder: Union(Select("a").From("t1"), Select("a").From("t2")),
+			d:       dialect.MySQL,
+			want:    "SELECT `a` FROM `t1` UNION SELECT `a` FROM `t2`",
+		},
+		{
+			builder: IntersectAll(Select("a").From("t1"), Select("a").From("t2")).OrderDesc("a").Limit(10).Offset(20),
+			d:       dialect.PostgreSQL,
+			want:    `SELECT "a" FROM "t1" INTERSECT ALL SELECT "a" FROM "t2" ORDER BY "a" DESC LIMIT 10 OFFSET 20`,
+		},
+		{
+			builder: Except(Select("a").From("t1"), Select("a").From("t2").OrderAsc("a").Limit(1)),
+			d:       dialect.PostgreSQL,
+			want:    `SELECT "a" FROM "t1" EXCEPT (SELECT "a" FROM "t2" ORDER BY "a" ASC LIMIT 1)`,
+		},
+		{
+			builder: Except(Select("a").From("t1"), Select("a").From("t2").OrderAsc("a").Limit(1)),
+			d:       dialect.SQLite3,
+			want:    `SELECT "a" FROM "t1" EXCEPT SELECT * FROM (SELECT "a" FROM "t2" ORDER BY "a" ASC LIMIT 1)`,
+		},
+		{
+			builder: Union(Select("a").From("t1"), Intersect(Select("a").From("t2"), Select("a").From("t3"))),
+			d:       dialect.MySQL,
+			want:    "SELECT `a` FROM `t1` UNION (SELECT `a` FROM `t2` INTERSECT SELECT `a` FROM `t3`)",
+		},
+		{
+			builder: Select("*").From(UnionAll(Select("a").From("t1"), Select("a").From("t2")).As("t")),
+			d:       dialect.SQLite3,
+			want:    `SELECT * FROM (SELECT "a" FROM "t1" UNION ALL SELECT "a" FROM "t2") AS "t"`,
+		},
+	} {
+		s, err := ToRawSql(test.d, test.builder)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+
+	_, err := ToRawSql(dialect.SQLite3, ExceptAll(Select("a").From("t1"), Select("a").From("t2")))
+	require.Equal(t, ErrNotSupported, err)
+}
+
+func TestSQLite3UnionLoad(t *testing.T) {
+	sess := sqlite3Session
+	reset(t, sess)
+
+	_, err := sess.InsertInto("dbx_people").
+		Columns("name", "email").
+		Values("test1", "test1@test.com").
+		Values("test2", "test2@test.com").
+		Values("test3", "test3@test.com").
+		Exec()
+	require.NoError(t, err)
+
+	var names []string
+	count, err := sess.Union(
+		Select("name").From("dbx_people").Where(Eq("id", 1)),
+		Select("name").From("dbx_people").Where(Gt("id", 1)),
+	).OrderDesc("name").Limit(2).Load(&names)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	require.Equal(t, []string{"test3", "test2"}, names)
+
+	var name string
+	err = sess.Except(
+		Select("name").From("dbx_people"),
+		Select("name").From("dbx_people").Where(Neq("id", 2)),
+	).LoadOne(&name)
+	require.NoError(t, err)
+	require.Equal(t, "test2", name)
+}
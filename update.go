@@ -3,7 +3,10 @@ package dbx
 import (
 	"context"
 	"database/sql"
+	"reflect"
+	"sort"
 	"strconv"
+	"time"
 )
 
 // UpdateStmt builds `UPDATE ...`.
@@ -16,9 +19,15 @@ type UpdateStmt struct {
 
 	Table        string
 	Value        map[string]interface{}
+	column       []string
+	changedOnly  bool
+	err          error
 	WhereCond    []Builder
 	ReturnColumn []string
 	LimitCount   int64
+	FromTable    interface{}
+	joins        []joinClause
+	with         withClause
 	comments     Comments
 }
 
@@ -33,6 +42,10 @@ func (b *UpdateStmt) Build(d Dialect, buf Buffer) error {
 		return ErrTableNotSpecified
 	}
 
+	if b.err != nil {
+		return b.err
+	}
+
 	if len(b.Value) == 0 {
 		return ErrColumnNotSpecified
 	}
@@ -42,33 +55,61 @@ func (b *UpdateStmt) Build(d Dialect, buf Buffer) error {
 		return err
 	}
 
+	err = b.with.Build(d, buf)
+	if err != nil {
+		return err
+	}
+
+	// SQL Server has TOP instead of LIMIT, and OUTPUT instead of RETURNING.
+	isMSSQL := d.DriverName() == "mssql"
+
 	buf.WriteString("UPDATE ")
+	if isMSSQL && b.LimitCount >= 0 {
+		buildTop(buf, b.LimitCount)
+	}
 	buf.WriteString(d.QuoteIdent(b.Table))
-	buf.WriteString(" SET ")
 
-	i := 0
-	for col, v := range b.Value {
-		if i > 0 {
+	// MySQL lists the other tables before SET; other dialects list them in FROM after SET.
+	isMySQL := d.DriverName() == "mysql"
+	if isMySQL {
+		if b.FromTable != nil {
 			buf.WriteString(", ")
+			buildTable(d, buf, b.FromTable)
+		}
+		for _, j := range b.joins {
+			err := j.Build(d, buf)
+			if err != nil {
+				return err
+			}
 		}
-		buf.WriteString(d.QuoteIdent(col))
-		buf.WriteString(" = ")
-		buf.WriteString(placeholder)
+	}
+
+	buf.WriteString(" SET ")
+	buildAssignments(d, buf, b.assignments())
 
-		buf.WriteValue(v)
+	if isMSSQL && len(b.ReturnColumn) > 0 {
+		buildOutput(d, buf, "INSERTED", b.ReturnColumn)
+	}
 
-		i++
+	whereCond := b.WhereCond
+	if !isMySQL && (b.FromTable != nil || len(b.joins) > 0) {
+		buf.WriteString(" FROM ")
+		on, err := buildJoinedTables(d, buf, b.FromTable, b.joins)
+		if err != nil {
+			return err
+		}
+		whereCond = mergeCond(on, whereCond)
 	}
 
-	if len(b.WhereCond) > 0 {
+	if len(whereCond) > 0 {
 		buf.WriteString(" WHERE ")
-		err := logicCond(b.WhereCond...).Build(d, buf)
+		err := logicCond(whereCond...).Build(d, buf)
 		if err != nil {
 			return err
 		}
 	}
 
-	if len(b.ReturnColumn) > 0 {
+	if len(b.ReturnColumn) > 0 && !isMSSQL {
 		buf.WriteString(" RETURNING ")
 		for i, col := range b.ReturnColumn {
 			if i > 0 {
@@ -78,7 +119,7 @@ func (b *UpdateStmt) Build(d Dialect, buf Buffer) error {
 		}
 	}
 
-	if b.LimitCount >= 0 {
+	if b.LimitCount >= 0 && !isMSSQL {
 		buf.WriteString(" LIMIT ")
 		buf.WriteString(strconv.FormatInt(b.LimitCount, 10))
 	}
@@ -160,37 +201,160 @@ func (b *UpdateStmt) Where(query interface{}, value ...interface{}) *UpdateStmt
 }
 
 // Returning specifies the returning columns for postgres.
+// In SQL Server, it is `OUTPUT INSERTED`, which fails on tables with triggers.
 func (b *UpdateStmt) Returning(column ...string) *UpdateStmt {
 	b.ReturnColumn = column
 	return b
 }
 
 // Set updates column with value.
+// Columns are written in the order they are first set.
 func (b *UpdateStmt) Set(column string, value interface{}) *UpdateStmt {
+	if _, ok := b.Value[column]; !ok {
+		b.column = append(b.column, column)
+	}
 	b.Value[column] = value
 	return b
 }
 
 // SetMap specifies a map of (column, value) to update in bulk.
+// Columns are set in sorted order.
 func (b *UpdateStmt) SetMap(m map[string]interface{}) *UpdateStmt {
-	for col, val := range m {
-		b.Set(col, val)
+	column := make([]string, 0, len(m))
+	for col := range m {
+		column = append(column, col)
+	}
+	sort.Strings(column)
+	for _, col := range column {
+		b.Set(col, m[col])
 	}
 	return b
 }
 
 // IncrBy increases column by value
 func (b *UpdateStmt) IncrBy(column string, value interface{}) *UpdateStmt {
-	b.Value[column] = Expr("? + ?", I(column), value)
-	return b
+	return b.Set(column, Expr("? + ?", I(column), value))
 }
 
 // DecrBy decreases column by value
 func (b *UpdateStmt) DecrBy(column string, value interface{}) *UpdateStmt {
-	b.Value[column] = Expr("? - ?", I(column), value)
+	return b.Set(column, Expr("? - ?", I(column), value))
+}
+
+// SetRecord updates columns with the fields of a struct.
+// Columns are named by `db` tag or NameMapping like in Record.
+// If column is empty, all columns but the key of the struct are set
+// in the order of fields. Otherwise only column is set in the given order.
+func (b *UpdateStmt) SetRecord(structValue interface{}, column ...string) *UpdateStmt {
+	v := reflect.Indirect(reflect.ValueOf(structValue))
+	if v.Kind() != reflect.Struct {
+		return b
+	}
+	s := newTagStore()
+	if len(column) == 0 {
+		key, _ := s.keyFields(v)
+		col, field := s.columns(v)
+		for i, fieldValue := range field {
+			if !containsString(key, col[i]) {
+				b.Set(col[i], fieldValue.Interface())
+			}
+		}
+		return b
+	}
+
+	found := make([]interface{}, len(column))
+	s.findValueByName(v, column, found, false)
+	for i, f := range found {
+		if f != nil {
+			b.Set(column[i], f.(reflect.Value).Interface())
+		}
+	}
+	return b
+}
+
+// SetChanged sets the columns of newValue whose fields differ from the same
+// columns of oldValue, which is usually the struct as it was loaded.
+// Columns are named like in SetRecord, and all of them are compared, including keys.
+// Fields are compared by reflect.DeepEqual, and times by time.Time.Equal.
+// A column missing in oldValue is always set.
+//
+// If nothing has changed, Exec executes nothing and returns a result with no rows
+// affected, but building the statement, e.g. by ToSql or Load, fails with
+// ErrColumnNotSpecified. Unless both values are structs or pointers to structs,
+// the statement fails with ErrNotSupported.
+func (b *UpdateStmt) SetChanged(oldValue, newValue interface{}) *UpdateStmt {
+	oldV := reflect.Indirect(reflect.ValueOf(oldValue))
+	newV := reflect.Indirect(reflect.ValueOf(newValue))
+	if oldV.Kind() != reflect.Struct || newV.Kind() != reflect.Struct {
+		b.err = ErrNotSupported
+		return b
+	}
+	b.changedOnly = true
+	s := newTagStore()
+	oldColumn, oldField := s.columns(oldV)
+	old := make(map[string]interface{}, len(oldColumn))
+	for i, col := range oldColumn {
+		old[col] = oldField[i].Interface()
+	}
+
+	column, field := s.columns(newV)
+	for i, col := range column {
+		value := field[i].Interface()
+		if prev, ok := old[col]; ok && equalValue(prev, value) {
+			continue
+		}
+		b.Set(col, value)
+	}
 	return b
 }
 
+// equalValue compares field values. Times are equal if they are the same instant.
+func equalValue(a, b interface{}) bool {
+	if t, ok := a.(time.Time); ok {
+		if u, ok := b.(time.Time); ok {
+			return t.Equal(u)
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func containsString(l []string, s string) bool {
+	for _, v := range l {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// assignments returns Value in the order of Set.
+// Columns added to Value directly follow in sorted order.
+func (b *UpdateStmt) assignments() []assignment {
+	set := make([]assignment, 0, len(b.Value))
+	seen := make(map[string]bool, len(b.column))
+	for _, col := range b.column {
+		if value, ok := b.Value[col]; ok && !seen[col] {
+			set = append(set, assignment{column: col, value: value})
+			seen[col] = true
+		}
+	}
+	if len(set) == len(b.Value) {
+		return set
+	}
+
+	var rest []string
+	for col := range b.Value {
+		if !seen[col] {
+			rest = append(rest, col)
+		}
+	}
+	sort.Strings(rest)
+	for _, col := range rest {
+		set = append(set, assignment{column: col, value: b.Value[col]})
+	}
+	return set
+}
+
 func (b *UpdateStmt) Limit(n uint64) *UpdateStmt {
 	b.LimitCount = int64(n)
 	return b
@@ -206,6 +370,10 @@ func (b *UpdateStmt) Exec() (sql.Result, error) {
 }
 
 func (b *UpdateStmt) ExecContext(ctx context.Context) (sql.Result, error) {
+	if b.changedOnly && b.err == nil && b.raw.Query == "" && len(b.Value) == 0 {
+		// nothing has changed
+		return &chunkResult{idErr: ErrNotSupported}, nil
+	}
 	return exec(ctx, b.runner, b.EventReceiver, b, b.Dialect)
 }
 
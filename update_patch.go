@@ -32,6 +32,49 @@ func (b *UpdateStmt) OrWhere(query interface{}, value ...interface{}) *UpdateStm
 	return b
 }
 
+// From adds another table to update from.
+// table can be Builder like SelectStmt, or string.
+//
+// It is `UPDATE ... FROM table` in PostgreSQL and SQLite3,
+// and `UPDATE ..., table` in MySQL.
+func (b *UpdateStmt) From(table interface{}) *UpdateStmt {
+	b.FromTable = table
+	return b
+}
+
+// Join add inner-join.
+// on can be Builder or string.
+//
+// In PostgreSQL and SQLite3, if From is not set, the first joined table
+// is moved to FROM, and on is added to WHERE.
+func (b *UpdateStmt) Join(table, on interface{}) *UpdateStmt {
+	b.joins = append(b.joins, joinClause{t: inner, table: table, on: on})
+	return b
+}
+
+// LeftJoin add left-join.
+// on can be Builder or string.
+//
+// In PostgreSQL and SQLite3, From must be set before LeftJoin.
+func (b *UpdateStmt) LeftJoin(table, on interface{}) *UpdateStmt {
+	b.joins = append(b.joins, joinClause{t: left, table: table, on: on})
+	return b
+}
+
+// With adds a common table expression named name.
+// builder can be SelectStmt, or any other Builder.
+func (b *UpdateStmt) With(name string, builder Builder) *UpdateStmt {
+	b.with = b.with.add(name, nil, builder, false)
+	return b
+}
+
+// WithRecursive adds a recursive common table expression named name.
+// column lists the column names of the table and can be empty.
+func (b *UpdateStmt) WithRecursive(name string, column []string, builder Builder) *UpdateStmt {
+	b.with = b.with.add(name, column, builder, true)
+	return b
+}
+
 // ToSql return the sql and args
 func (b *UpdateStmt) ToSql() (string, []interface{}, error) {
 	return ToSql(b.Dialect, b)
@@ -2,6 +2,7 @@ package dbx
 
 import (
 	"testing"
+	"time"
 
 	"github.com/gokit/dbx/dialect"
 	"github.com/stretchr/testify/require"
@@ -60,3 +61,141 @@ func TestUpdateIncrBy(t *testing.T) {
 
 	require.Equal(t, "UPDATE `table` SET `a` = `a` + 1 WHERE (`b` = 2)", sqlstr)
 }
+
+func TestUpdateWith(t *testing.T) {
+	builder := Update("orders").
+		With("stale", Select("id").From("orders").Where(Lt("updated_at", "2020-01-01"))).
+		Set("archived", true).
+		Where(Expr("id IN (SELECT id FROM stale)"))
+	s, err := ToRawSql(dialect.PostgreSQL, builder)
+	require.NoError(t, err)
+	require.Equal(t, `WITH "stale" AS (SELECT "id" FROM "orders" WHERE "updated_at" < '2020-01-01') UPDATE "orders" SET "archived" = TRUE WHERE id IN (SELECT id FROM stale)`, s)
+}
+
+func TestUpdateJoin(t *testing.T) {
+	for _, test := range []struct {
+		builder Builder
+		d       Dialect
+		want    string
+	}{
+		{
+			builder: Update("orders").
+				Join("users", "orders.user_id = users.id").
+				Set("orders.status", "banned").
+				Where(Eq("users.banned", true)),
+			d:    dialect.MySQL,
+			want: "UPDATE `orders` JOIN `users` ON orders.user_id = users.id SET `orders`.`status` = 'banned' WHERE `users`.`banned` = 1",
+		},
+		{
+			builder: Update("orders").
+				Join("users", "orders.user_id = users.id").
+				Set("status", "banned").
+				Where(Eq("users.banned", true)).
+				OrWhere(Eq("users.deleted", true)),
+			d:    dialect.PostgreSQL,
+			want: `UPDATE "orders" SET "status" = 'banned' FROM "users" WHERE (orders.user_id = users.id) AND (("users"."banned" = TRUE) OR ("users"."deleted" = TRUE))`,
+		},
+		{
+			builder: Update("orders").
+				From("users").
+				LeftJoin("groups", "users.group_id = groups.id").
+				Set("status", Expr("groups.status")).
+				Where("orders.user_id = users.id"),
+			d:    dialect.SQLite3,
+			want: `UPDATE "orders" SET "status" = groups.status FROM "users" LEFT JOIN "groups" ON users.group_id = groups.id WHERE orders.user_id = users.id`,
+		},
+		{
+			builder: Update("orders").
+				From("users").
+				Set("orders.status", "banned").
+				Where("orders.user_id = users.id"),
+			d:    dialect.MySQL,
+			want: "UPDATE `orders`, `users` SET `orders`.`status` = 'banned' WHERE orders.user_id = users.id",
+		},
+	} {
+		s, err := ToRawSql(test.d, test.builder)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+
+	_, err := ToRawSql(dialect.PostgreSQL, Update("orders").LeftJoin("users", "orders.user_id = users.id").Set("a", 1))
+	require.Equal(t, ErrNotSupported, err)
+}
+
+func TestUpdateSetOrder(t *testing.T) {
+	builder := Update("table").Set("c", 3).Set("a", 1).SetMap(map[string]interface{}{"d": 4, "b": 2}).Set("c", 5)
+	for i := 0; i < 10; i++ {
+		s, err := ToRawSql(dialect.MySQL, builder)
+		require.NoError(t, err)
+		require.Equal(t, "UPDATE `table` SET `c` = 5, `a` = 1, `b` = 2, `d` = 4", s)
+	}
+}
+
+func TestUpdateSetRecord(t *testing.T) {
+	type account struct {
+		ID        int64 `db:"id,pk"`
+		Name      string
+		Email     string `db:"mail"`
+		UpdatedAt time.Time
+		Ignored   string `db:"-"`
+	}
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	a := &account{ID: 1, Name: "a", Email: "a@b.c", UpdatedAt: now}
+
+	s, err := ToRawSql(dialect.PostgreSQL, Update("accounts").SetRecord(a).Where(Eq("id", a.ID)))
+	require.NoError(t, err)
+	require.Equal(t, `UPDATE "accounts" SET "name" = 'a', "mail" = 'a@b.c', "updated_at" = '2020-01-02 03:04:05.000000' WHERE "id" = 1`, s)
+
+	s, err = ToRawSql(dialect.PostgreSQL, Update("accounts").SetRecord(a, "mail", "name", "missing"))
+	require.NoError(t, err)
+	require.Equal(t, `UPDATE "accounts" SET "mail" = 'a@b.c', "name" = 'a'`, s)
+
+	b := *a
+	b.Email = "b@b.c"
+	b.UpdatedAt = now.In(time.FixedZone("", 3600))
+	b.Ignored = "x"
+	s, err = ToRawSql(dialect.PostgreSQL, Update("accounts").SetChanged(a, &b).Where(Eq("id", a.ID)))
+	require.NoError(t, err)
+	require.Equal(t, `UPDATE "accounts" SET "mail" = 'b@b.c' WHERE "id" = 1`, s)
+
+	_, err = ToRawSql(dialect.PostgreSQL, Update("accounts").SetChanged(a, *a))
+	require.Equal(t, ErrColumnNotSpecified, err)
+}
+
+func TestSQLite3UpdateSetRecord(t *testing.T) {
+	sess := sqlite3Session
+	reset(t, sess)
+
+	p := dbxPerson{Name: "a", Email: "a@b.c"}
+	_, err := sess.InsertInto("dbx_people").Columns("name", "email").Record(&p).Exec()
+	require.NoError(t, err)
+
+	changed := p
+	changed.Name = "b"
+	_, err = sess.Update("dbx_people").SetChanged(p, changed).Where(Eq("id", p.Id)).Exec()
+	require.NoError(t, err)
+	changed.Email = "b@b.c"
+	_, err = sess.Update("dbx_people").SetRecord(changed).Where(Eq("id", p.Id)).Exec()
+	require.NoError(t, err)
+
+	// saving without changes is a no-op
+	result, err := sess.Update("dbx_people").SetChanged(changed, changed).Where(Eq("id", p.Id)).Exec()
+	require.NoError(t, err)
+	n, err := result.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+	_, _, err = sess.Update("dbx_people").SetChanged(changed, changed).Where(Eq("id", p.Id)).ToSql()
+	require.Equal(t, ErrColumnNotSpecified, err)
+
+	// values other than structs are an error rather than no changes
+	var nilPerson *dbxPerson
+	for _, old := range []interface{}{nil, nilPerson, map[string]interface{}{"name": "a"}} {
+		_, err = sess.Update("dbx_people").SetChanged(old, changed).Where(Eq("id", p.Id)).Exec()
+		require.Equal(t, ErrNotSupported, err)
+	}
+
+	var got dbxPerson
+	err = sess.Select("*").From("dbx_people").Where(Eq("id", p.Id)).LoadOne(&got)
+	require.NoError(t, err)
+	require.Equal(t, changed, got)
+}
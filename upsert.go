@@ -0,0 +1,91 @@
+package dbx
+
+// onConflict holds the conflict clause of an InsertStmt.
+type onConflict struct {
+	column  []string
+	nothing bool
+	set     []assignment
+}
+
+// assignment is a single `column = value` of a SET list.
+type assignment struct {
+	column string
+	value  interface{}
+}
+
+func buildAssignments(d Dialect, buf Buffer, set []assignment) {
+	for i, a := range set {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(d.QuoteIdent(a.column))
+		buf.WriteString(" = ")
+		buf.WriteString(placeholder)
+		buf.WriteValue(a.value)
+	}
+}
+
+// build writes the conflict clause with a leading space.
+//
+// MySQL has no conflict target, so `DO NOTHING` is written as a no-op
+// `ON DUPLICATE KEY UPDATE` that assigns a column to itself.
+func (c *onConflict) build(d Dialect, buf Buffer, insertColumn []string) error {
+	switch d.DriverName() {
+	case "mssql":
+		// SQL Server only has MERGE
+		return ErrNotSupported
+	case "mysql":
+		buf.WriteString(" ON DUPLICATE KEY UPDATE ")
+		if c.nothing || len(c.set) == 0 {
+			column := c.column
+			if len(column) == 0 {
+				column = insertColumn
+			}
+			if len(column) == 0 {
+				return ErrColumnNotSpecified
+			}
+			col := d.QuoteIdent(column[0])
+			buf.WriteString(col)
+			buf.WriteString(" = ")
+			buf.WriteString(col)
+			return nil
+		}
+		buildAssignments(d, buf, c.set)
+		return nil
+	}
+
+	buf.WriteString(" ON CONFLICT")
+	if len(c.column) > 0 {
+		buf.WriteString(" (")
+		for i, col := range c.column {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString(d.QuoteIdent(col))
+		}
+		buf.WriteString(")")
+	}
+	if c.nothing || len(c.set) == 0 {
+		buf.WriteString(" DO NOTHING")
+		return nil
+	}
+	buf.WriteString(" DO UPDATE SET ")
+	buildAssignments(d, buf, c.set)
+	return nil
+}
+
+// Excluded refers to the value of column in the row proposed for insertion.
+// It is `EXCLUDED.column` in PostgreSQL and SQLite3, and `VALUES(column)` in MySQL.
+func Excluded(column string) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		if d.DriverName() == "mysql" {
+			buf.WriteString("VALUES(")
+			buf.WriteString(d.QuoteIdent(column))
+			buf.WriteString(")")
+			return nil
+		}
+		buf.WriteString("EXCLUDED.")
+		buf.WriteString(d.QuoteIdent(column))
+		return nil
+	})
+}
@@ -47,6 +47,23 @@ var (
 	typeValuer = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
 )
 
+// parseTag splits a db tag like `id,pk` into the column name and options.
+func parseTag(tag string) (string, []string) {
+	l := strings.Split(tag, ",")
+	return l[0], l[1:]
+}
+
+// hasTagOption tells whether a db tag has an option like `pk`.
+func hasTagOption(tag, option string) bool {
+	_, opt := parseTag(tag)
+	for _, o := range opt {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
 type tagStore struct {
 	m map[reflect.Type][]string
 }
@@ -69,7 +86,7 @@ func (s *tagStore) get(t reflect.Type) []string {
 				// unexported
 				continue
 			}
-			tag := field.Tag.Get("db")
+			tag, _ := parseTag(field.Tag.Get("db"))
 			if tag == "-" {
 				// ignore
 				continue
@@ -85,6 +102,78 @@ func (s *tagStore) get(t reflect.Type) []string {
 	return s.m[t]
 }
 
+// columns returns the columns of a struct and the fields for them in order.
+// Fields of embedded structs without a db tag are included.
+func (s *tagStore) columns(value reflect.Value) ([]string, []reflect.Value) {
+	var column []string
+	var field []reflect.Value
+	l := s.get(value.Type())
+	for i := 0; i < value.NumField(); i++ {
+		structField := value.Type().Field(i)
+		fieldValue := value.Field(i)
+		if structField.Anonymous && structField.Tag.Get("db") == "" {
+			embedded := reflect.Indirect(fieldValue)
+			if embedded.Kind() == reflect.Struct && !fieldValue.Type().Implements(typeValuer) {
+				c, f := s.columns(embedded)
+				column = append(column, c...)
+				field = append(field, f...)
+				continue
+			}
+		}
+		if l[i] == "" || !fieldValue.CanInterface() {
+			continue
+		}
+		column = append(column, l[i])
+		field = append(field, fieldValue)
+	}
+	return column, field
+}
+
+// keyFields returns the columns of the key of a struct and pointers to the
+// fields. Key fields are tagged with `pk` like `db:"id,pk"`, and more than one
+// make a composite key. Without the tag, the field for column id is the key.
+// Pointers are nil if the struct cannot be set.
+func (s *tagStore) keyFields(value reflect.Value) ([]string, []interface{}) {
+	var column []string
+	var ptr []interface{}
+	s.findKeyFields(value, &column, &ptr)
+	if len(column) > 0 {
+		return column, ptr
+	}
+
+	found := make([]interface{}, 1)
+	s.findValueByName(value, []string{"id"}, found, false)
+	if field, ok := found[0].(reflect.Value); ok {
+		column = []string{"id"}
+		ptr = []interface{}{nil}
+		if field.CanSet() {
+			ptr[0] = field.Addr().Interface()
+		}
+	}
+	return column, ptr
+}
+
+func (s *tagStore) findKeyFields(value reflect.Value, column *[]string, ptr *[]interface{}) {
+	l := s.get(value.Type())
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		fieldValue := value.Field(i)
+		if field.Anonymous && fieldValue.Kind() == reflect.Struct {
+			s.findKeyFields(fieldValue, column, ptr)
+			continue
+		}
+		if l[i] == "" || !hasTagOption(field.Tag.Get("db"), "pk") {
+			continue
+		}
+		*column = append(*column, l[i])
+		if fieldValue.CanSet() {
+			*ptr = append(*ptr, fieldValue.Addr().Interface())
+		} else {
+			*ptr = append(*ptr, nil)
+		}
+	}
+}
+
 func (s *tagStore) findPtr(value reflect.Value, name []string, ptr []interface{}) error {
 	if value.CanAddr() && value.Addr().Type().Implements(typeScanner) {
 		ptr[0] = value.Addr().Interface()
@@ -5,12 +5,23 @@ import (
 	"strings"
 )
 
+// QuoteIdent quotes each dot separated part of s.
+// quote inside s is escaped by doubling it.
 func QuoteIdent(s, quote string) string {
 	part := strings.SplitN(s, ".", 2)
 	if len(part) == 2 {
 		return QuoteIdent(part[0], quote) + "." + QuoteIdent(part[1], quote)
 	}
-	return quote + s + quote
+	return quote + strings.Replace(s, quote, quote+quote, -1) + quote
+}
+
+// QuoteIdentBrackets quotes s like QuoteIdent, but with `[` and `]` of SQL Server.
+func QuoteIdentBrackets(s string) string {
+	part := strings.SplitN(s, ".", 2)
+	if len(part) == 2 {
+		return QuoteIdentBrackets(part[0]) + "." + QuoteIdentBrackets(part[1])
+	}
+	return "[" + strings.Replace(s, "]", "]]", -1) + "]"
 }
 
 func QuoteIdents(idents []string, quoteIdent func(s string) string) string {
@@ -0,0 +1,96 @@
+package dbx
+
+import (
+	"reflect"
+	"sort"
+)
+
+// WhereOption changes how WhereStruct picks fields.
+type WhereOption func(opt *whereOption)
+
+type whereOption struct {
+	includeZero bool
+}
+
+// IncludeZero makes WhereStruct compare fields with zero values too.
+// A nil pointer or slice is then compared with `IS NULL`.
+func IncludeZero() WhereOption {
+	return func(opt *whereOption) {
+		opt.includeZero = true
+	}
+}
+
+// eq is Eq, but []byte is compared as a single value.
+func eq(column string, value interface{}) Builder {
+	if _, ok := value.([]byte); ok {
+		return BuildFunc(func(d Dialect, buf Buffer) error {
+			return buildCmp(d, buf, "=", column, value)
+		})
+	}
+	return Eq(column, value)
+}
+
+// buildEqs writes an AND of cond, or a true condition if cond is empty.
+func buildEqs(d Dialect, buf Buffer, cond []Builder) error {
+	if len(cond) == 0 {
+		buf.WriteString(d.EncodeBool(true))
+		return nil
+	}
+	return And(cond...).Build(d, buf)
+}
+
+// WhereMap creates an AND of Eq for each key and value in m.
+// Keys are sorted, so the same map always builds the same SQL.
+func WhereMap(m map[string]interface{}) Builder {
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		column := make([]string, 0, len(m))
+		for col := range m {
+			column = append(column, col)
+		}
+		sort.Strings(column)
+
+		cond := make([]Builder, len(column))
+		for i, col := range column {
+			cond[i] = eq(col, m[col])
+		}
+		return buildEqs(d, buf, cond)
+	})
+}
+
+// WhereStruct creates an AND of Eq for each non-zero field of a struct.
+// Columns are named by `db` tag or NameMapping like in Record,
+// and fields of embedded structs are included.
+func WhereStruct(value interface{}, opt ...WhereOption) Builder {
+	var o whereOption
+	for _, f := range opt {
+		f(&o)
+	}
+	return BuildFunc(func(d Dialect, buf Buffer) error {
+		v := reflect.Indirect(reflect.ValueOf(value))
+		if v.Kind() != reflect.Struct {
+			return ErrNotSupported
+		}
+		var cond []Builder
+		structEqs(newTagStore(), v, o, &cond)
+		return buildEqs(d, buf, cond)
+	})
+}
+
+func structEqs(s *tagStore, v reflect.Value, o whereOption, cond *[]Builder) {
+	column, field := s.columns(v)
+	for i, fieldValue := range field {
+		if !o.includeZero && fieldValue.IsZero() {
+			continue
+		}
+		var value interface{}
+		switch fieldValue.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+			if !fieldValue.IsNil() {
+				value = fieldValue.Interface()
+			}
+		default:
+			value = fieldValue.Interface()
+		}
+		*cond = append(*cond, eq(column[i], value))
+	}
+}
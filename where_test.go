@@ -0,0 +1,69 @@
+package dbx
+
+import (
+	"testing"
+
+	"github.com/gokit/dbx/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWhereStruct(t *testing.T) {
+	type base struct {
+		TenantID int64
+	}
+	type filter struct {
+		base
+		Name     string `db:"user_name"`
+		Age      int
+		Tags     []string
+		Email    *string
+		Hash     []byte
+		Ignored  string `db:"-"`
+		internal string
+	}
+	email := "a@b.c"
+
+	for _, test := range []struct {
+		builder Builder
+		d       Dialect
+		want    string
+	}{
+		{
+			builder: WhereStruct(filter{base: base{TenantID: 7}, Name: "bob", Tags: []string{"a", "b"}, Ignored: "x", internal: "x"}),
+			d:       dialect.MySQL,
+			want:    "(`tenant_id` = 7) AND (`user_name` = 'bob') AND (`tags` IN ('a','b'))",
+		},
+		{
+			builder: WhereStruct(&filter{Email: &email, Hash: []byte{1}}),
+			d:       dialect.PostgreSQL,
+			want:    `("email" = 'a@b.c') AND ("hash" = $1)`,
+		},
+		{
+			builder: WhereStruct(filter{Name: "bob"}, IncludeZero()),
+			d:       dialect.SQLite3,
+			want:    `("tenant_id" = 0) AND ("user_name" = 'bob') AND ("age" = 0) AND ("tags" IS NULL) AND ("email" IS NULL) AND ("hash" IS NULL)`,
+		},
+		{
+			builder: WhereStruct(filter{}),
+			d:       dialect.PostgreSQL,
+			want:    `TRUE`,
+		},
+		{
+			builder: WhereMap(map[string]interface{}{"b": nil, "a": 1, "c": []int{1, 2}}),
+			d:       dialect.MySQL,
+			want:    "(`a` = 1) AND (`b` IS NULL) AND (`c` IN (1,2))",
+		},
+		{
+			builder: Select("id").From("users").Where(WhereMap(map[string]interface{}{"id": 1})).AndWhere(Gt("age", 18)),
+			d:       dialect.MySQL,
+			want:    "SELECT `id` FROM `users` WHERE (`id` = 1) AND (`age` > 18)",
+		},
+	} {
+		s, err := ToRawSql(test.d, test.builder)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+
+	_, err := ToRawSql(dialect.MySQL, WhereStruct(1))
+	require.Equal(t, ErrNotSupported, err)
+}
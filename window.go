@@ -0,0 +1,197 @@
+package dbx
+
+import "strconv"
+
+// FrameBound is a bound of a window frame like `UNBOUNDED PRECEDING`.
+type FrameBound string
+
+// frame bounds
+const (
+	UnboundedPreceding FrameBound = "UNBOUNDED PRECEDING"
+	CurrentRow         FrameBound = "CURRENT ROW"
+	UnboundedFollowing FrameBound = "UNBOUNDED FOLLOWING"
+)
+
+// Preceding is the bound n rows or values before the current row.
+func Preceding(n uint64) FrameBound {
+	return FrameBound(strconv.FormatUint(n, 10) + " PRECEDING")
+}
+
+// Following is the bound n rows or values after the current row.
+func Following(n uint64) FrameBound {
+	return FrameBound(strconv.FormatUint(n, 10) + " FOLLOWING")
+}
+
+// Window builds a window specification like
+// `PARTITION BY ... ORDER BY ... ROWS BETWEEN ... AND ...`.
+type Window struct {
+	base      string
+	partition []string
+	order     []Builder
+	frame     string
+	start     FrameBound
+	end       FrameBound
+}
+
+// NewWindow creates an empty Window.
+func NewWindow() *Window {
+	return &Window{}
+}
+
+// NamedWindow creates a Window based on a window defined by SelectStmt.Window.
+func NamedWindow(name string) *Window {
+	return &Window{base: name}
+}
+
+// PartitionBy specifies columns for partitioning.
+func (w *Window) PartitionBy(col ...string) *Window {
+	w.partition = append(w.partition, col...)
+	return w
+}
+
+// OrderAsc sorts rows in a partition by col in ascending order.
+func (w *Window) OrderAsc(col string) *Window {
+	w.order = append(w.order, order(col, asc))
+	return w
+}
+
+// OrderDesc sorts rows in a partition by col in descending order.
+func (w *Window) OrderDesc(col string) *Window {
+	w.order = append(w.order, order(col, desc))
+	return w
+}
+
+// Rows specifies the frame as `ROWS BETWEEN start AND end`.
+func (w *Window) Rows(start, end FrameBound) *Window {
+	w.frame, w.start, w.end = "ROWS", start, end
+	return w
+}
+
+// Range specifies the frame as `RANGE BETWEEN start AND end`.
+func (w *Window) Range(start, end FrameBound) *Window {
+	w.frame, w.start, w.end = "RANGE", start, end
+	return w
+}
+
+// isRef tells whether w only refers to a named window.
+func (w *Window) isRef() bool {
+	return w.base != "" && len(w.partition) == 0 && len(w.order) == 0 && w.frame == ""
+}
+
+// Build writes the specification without parentheses.
+func (w *Window) Build(d Dialect, buf Buffer) error {
+	sep := ""
+	if w.base != "" {
+		buf.WriteString(d.QuoteIdent(w.base))
+		sep = " "
+	}
+	if len(w.partition) > 0 {
+		buf.WriteString(sep)
+		buf.WriteString("PARTITION BY ")
+		for i, col := range w.partition {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buildColumn(d, buf, col)
+		}
+		sep = " "
+	}
+	if len(w.order) > 0 {
+		buf.WriteString(sep)
+		buf.WriteString("ORDER BY ")
+		for i, order := range w.order {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			err := order.Build(d, buf)
+			if err != nil {
+				return err
+			}
+		}
+		sep = " "
+	}
+	if w.frame != "" {
+		buf.WriteString(sep)
+		buf.WriteString(w.frame)
+		buf.WriteString(" BETWEEN ")
+		buf.WriteString(string(w.start))
+		buf.WriteString(" AND ")
+		buf.WriteString(string(w.end))
+	}
+	return nil
+}
+
+// WindowFunc builds `fn OVER (...)`.
+type WindowFunc struct {
+	fn     interface{}
+	window *Window
+}
+
+// Over creates a WindowFunc.
+// fn can be Builder, or string as raw SQL like `ROW_NUMBER()`.
+// If window is nil, fn is applied over all rows.
+func Over(fn interface{}, window *Window) *WindowFunc {
+	if query, ok := fn.(string); ok {
+		fn = Expr(query)
+	}
+	return &WindowFunc{
+		fn:     fn,
+		window: window,
+	}
+}
+
+func (f *WindowFunc) Build(d Dialect, buf Buffer) error {
+	buf.WriteString(placeholder)
+	buf.WriteValue(f.fn)
+	buf.WriteString(" OVER ")
+	if f.window != nil && f.window.isRef() {
+		buf.WriteString(d.QuoteIdent(f.window.base))
+		return nil
+	}
+	buf.WriteString("(")
+	if f.window != nil {
+		err := f.window.Build(d, buf)
+		if err != nil {
+			return err
+		}
+	}
+	buf.WriteString(")")
+	return nil
+}
+
+// As creates alias for the window function.
+func (f *WindowFunc) As(alias string) Builder {
+	return as(f, alias)
+}
+
+// namedWindow is a window in the WINDOW clause of SelectStmt.
+type namedWindow struct {
+	name   string
+	window *Window
+}
+
+// Window defines a named window to be used by NamedWindow.
+func (b *SelectStmt) Window(name string, window *Window) *SelectStmt {
+	b.windows = append(b.windows, namedWindow{name: name, window: window})
+	return b
+}
+
+func (b *SelectStmt) buildWindows(d Dialect, buf Buffer) error {
+	if len(b.windows) == 0 {
+		return nil
+	}
+	buf.WriteString(" WINDOW ")
+	for i, w := range b.windows {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(d.QuoteIdent(w.name))
+		buf.WriteString(" AS (")
+		err := w.window.Build(d, buf)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(")")
+	}
+	return nil
+}
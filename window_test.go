@@ -0,0 +1,65 @@
+package dbx
+
+import (
+	"testing"
+
+	"github.com/gokit/dbx/dialect"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWindow(t *testing.T) {
+	for _, test := range []struct {
+		builder Builder
+		d       Dialect
+		want    string
+	}{
+		{
+			builder: Select("id", Over("ROW_NUMBER()", NewWindow().PartitionBy("dept").OrderDesc("salary")).As("rank")).From("employees"),
+			d:       dialect.PostgreSQL,
+			want:    `SELECT "id", ROW_NUMBER() OVER (PARTITION BY "dept" ORDER BY "salary" DESC) AS "rank" FROM "employees"`,
+		},
+		{
+			builder: Over(Expr("SUM(?)", I("amount")), NewWindow().OrderAsc("day").Rows(Preceding(6), CurrentRow)),
+			d:       dialect.MySQL,
+			want:    "SUM(`amount`) OVER (ORDER BY `day` ASC ROWS BETWEEN 6 PRECEDING AND CURRENT ROW)",
+		},
+		{
+			builder: Over("COUNT(*)", nil),
+			d:       dialect.SQLite3,
+			want:    `COUNT(*) OVER ()`,
+		},
+		{
+			builder: Select("id", Over("SUM(amount)", NamedWindow("w")).As("total"), Over("AVG(amount)", NamedWindow("w").Range(UnboundedPreceding, UnboundedFollowing))).
+				From("payments").
+				Window("w", NewWindow().PartitionBy("user_id", "t.kind").OrderAsc("id")).
+				OrderAsc("id"),
+			d: dialect.SQLite3,
+			want: `SELECT "id", SUM(amount) OVER "w" AS "total", AVG(amount) OVER ("w" RANGE BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING) ` +
+				`FROM "payments" WINDOW "w" AS (PARTITION BY "user_id", "t"."kind" ORDER BY "id" ASC) ORDER BY "id" ASC`,
+		},
+	} {
+		s, err := ToRawSql(test.d, test.builder)
+		require.NoError(t, err)
+		require.Equal(t, test.want, s)
+	}
+}
+
+func TestSQLite3Window(t *testing.T) {
+	sess := sqlite3Session
+	reset(t, sess)
+
+	for _, name := range []string{"a", "b", "c"} {
+		_, err := sess.InsertInto("dbx_people").Pair("name", name).Pair("email", name+"@test.com").Exec()
+		require.NoError(t, err)
+	}
+
+	var rank []int
+	_, err := sess.Select().
+		AddColumn(Over("ROW_NUMBER()", NamedWindow("w"))).
+		From("dbx_people").
+		Window("w", NewWindow().OrderDesc("name")).
+		OrderAsc("id").
+		Load(&rank)
+	require.NoError(t, err)
+	require.Equal(t, []int{3, 2, 1}, rank)
+}
@@ -0,0 +1,60 @@
+package dbx
+
+// commonTable is a single named subquery in a WITH clause.
+type commonTable struct {
+	name      string
+	column    []string
+	builder   Builder
+	recursive bool
+}
+
+// withClause builds `WITH [RECURSIVE] name [(col, ...)] AS (...), ...`.
+type withClause []*commonTable
+
+func (w withClause) add(name string, column []string, builder Builder, recursive bool) withClause {
+	return append(w, &commonTable{
+		name:      name,
+		column:    column,
+		builder:   builder,
+		recursive: recursive,
+	})
+}
+
+// Build writes the WITH clause followed by a space, or nothing if it is empty.
+func (w withClause) Build(d Dialect, buf Buffer) error {
+	if len(w) == 0 {
+		return nil
+	}
+
+	buf.WriteString("WITH ")
+	for _, t := range w {
+		// RECURSIVE applies to the whole clause, not a single table
+		if t.recursive {
+			buf.WriteString("RECURSIVE ")
+			break
+		}
+	}
+
+	for i, t := range w {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(d.QuoteIdent(t.name))
+		if len(t.column) > 0 {
+			buf.WriteString(" (")
+			for i, col := range t.column {
+				if i > 0 {
+					buf.WriteString(", ")
+				}
+				buf.WriteString(d.QuoteIdent(col))
+			}
+			buf.WriteString(")")
+		}
+		buf.WriteString(" AS (")
+		buf.WriteString(placeholder)
+		buf.WriteValue(bare(t.builder))
+		buf.WriteString(")")
+	}
+	buf.WriteString(" ")
+	return nil
+}